@@ -3,12 +3,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/ecosystem"
 	"github.com/cyber-kamil/depflow/internal/model"
-	"github.com/cyber-kamil/depflow/internal/parse"
 	"github.com/cyber-kamil/depflow/internal/report"
 	"github.com/cyber-kamil/depflow/internal/scan"
+	"github.com/cyber-kamil/depflow/internal/version"
 	"github.com/spf13/cobra"
 )
 
@@ -17,144 +20,81 @@ var (
 	output string
 )
 
-func scanForNpmLockFile(dir string) (string, error) {
-	found, err := scan.ScanForLockFiles(dir)
+// checkDependencies parses the lock file at lockPath with the given ecosystem,
+// looks up the latest versions, splits the dependencies into report sections
+// and fetches changelogs for the outdated dependencies the sections list.
+func checkDependencies(eco ecosystem.Ecosystem, lockPath string) ([]ecosystem.Section, map[string]*model.ChangelogInfo, error) {
+	deps, err := eco.Parse(lockPath)
 	if err != nil {
-		return "", err
-	}
-	for _, lf := range found {
-		if lf == "package-lock.json" {
-			return dir + "/" + lf, nil
-		}
+		return nil, nil, err
 	}
-	return "", nil
-}
-
-func checkNpmDependencies(lockPath string) ([]report.NpmDepReport, map[string]*model.ChangelogInfo, error) {
-	deps, err := parse.ParseNpmLockFile(lockPath)
-	if err != nil {
+	if err := eco.ResolveLatest(filepath.Dir(lockPath), deps); err != nil {
 		return nil, nil, err
 	}
-	reports := []report.NpmDepReport{}
-	changelogs := make(map[string]*model.ChangelogInfo)
-	for name, current := range deps {
-		latest, err := check.GetNpmLatestVersion(name)
-		if err != nil {
-			reports = append(reports, report.NpmDepReport{
-				Name:     name,
-				Current:  current,
-				Latest:   "",
-				Outdated: false,
-			})
-			continue
-		}
-		outdated := current != latest
-		reports = append(reports, report.NpmDepReport{
-			Name:     name,
-			Current:  current,
-			Latest:   latest,
-			Outdated: outdated,
-		})
-		if outdated {
-			info, err := check.FetchChangelogInfo(name, current, latest)
-			if err == nil && info != nil {
-				changelogs[name] = info
-			}
-		}
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	scheme := version.ForEcosystem(eco.Name())
+	for i := range deps {
+		classifyUpdate(scheme, &deps[i])
 	}
-	return reports, changelogs, nil
-}
 
-func scanForYarnLockFile(dir string) (string, error) {
-	found, err := scan.ScanForLockFiles(dir)
-	if err != nil {
-		return "", err
-	}
-	for _, lf := range found {
-		if lf == "yarn.lock" {
-			return dir + "/" + lf, nil
+	sections := []ecosystem.Section{{Title: eco.Title(), Dependencies: deps}}
+	if s, ok := eco.(ecosystem.Sectioner); ok {
+		if split := s.Sections(lockPath, deps); split != nil {
+			sections = split
 		}
 	}
-	return "", nil
-}
 
-func checkYarnDependencies(lockPath string) ([]report.NpmDepReport, map[string]*model.ChangelogInfo, error) {
-	deps, err := parse.ParseYarnLockFile(lockPath)
-	if err != nil {
-		return nil, nil, err
-	}
-	reports := []report.NpmDepReport{}
+	// Only dependencies shown in the report get changelogs; hidden ones, such
+	// as indirect Go modules, would spend API rate limits for nothing.
 	changelogs := make(map[string]*model.ChangelogInfo)
-	for name, current := range deps {
-		latest, err := check.GetNpmLatestVersion(name)
-		if err != nil {
-			reports = append(reports, report.NpmDepReport{
-				Name:     name,
-				Current:  current,
-				Latest:   "",
-				Outdated: false,
-			})
-			continue
-		}
-		outdated := current != latest
-		reports = append(reports, report.NpmDepReport{
-			Name:     name,
-			Current:  current,
-			Latest:   latest,
-			Outdated: outdated,
-		})
-		if outdated {
-			info, err := check.FetchChangelogInfo(name, current, latest)
+	for _, section := range sections {
+		for _, dep := range section.Dependencies {
+			if !dep.Outdated {
+				continue
+			}
+			if _, ok := changelogs[dep.Name]; ok {
+				continue
+			}
+			info, err := eco.FetchChangelog(dep.Name, dep.Version, dep.Latest)
 			if err == nil && info != nil {
-				changelogs[name] = info
+				changelogs[dep.Name] = info
 			}
 		}
 	}
-	return reports, changelogs, nil
+	return sections, changelogs, nil
 }
 
-// Add a type for the Go version checker function
-// This allows us to inject a mock in tests
-
-type GoVersionChecker func(dir string) (map[string]string, error)
-
-func checkGoDependencies(dir string, goModPath string, versionChecker GoVersionChecker) ([]report.NpmDepReport, map[string]*model.ChangelogInfo, error) {
-	mods, err := parse.ParseGoModFile(goModPath)
-	if err != nil {
-		return nil, nil, err
+// classifyUpdate sets Outdated and UpdateType. A latest version older than the
+// current one is not an update; versions the scheme cannot order are outdated
+// whenever they differ.
+func classifyUpdate(scheme version.Scheme, dep *model.Dependency) {
+	dep.Outdated, dep.UpdateType = false, ""
+	if dep.Version == "" || dep.Latest == "" {
+		return
 	}
-	latest, err := versionChecker(dir)
-	if err != nil {
-		return nil, nil, err
-	}
-	reports := []report.NpmDepReport{}
-	changelogs := make(map[string]*model.ChangelogInfo)
-	for name, current := range mods {
-		newest, hasUpdate := latest[name]
-		outdated := hasUpdate && current != newest
-		reports = append(reports, report.NpmDepReport{
-			Name:     name,
-			Current:  current,
-			Latest:   newest,
-			Outdated: outdated,
-		})
-		if outdated {
-			info, err := check.FetchChangelogInfo(name, current, newest)
-			if err == nil && info != nil {
-				changelogs[name] = info
-			}
-		}
+	update, ok := version.Classify(scheme, dep.Version, dep.Latest)
+	if !ok {
+		dep.Outdated = dep.Version != dep.Latest
+		return
 	}
-	return reports, changelogs, nil
+	dep.Outdated = update != version.NoUpdate
+	dep.UpdateType = string(update)
 }
 
-func writeMarkdownReport(reports []report.NpmDepReport, output string) error {
-	md := report.GenerateNpmMarkdownReport(reports, map[string]*model.ChangelogInfo{})
+func writeMarkdownReport(reports []model.Dependency, output string) error {
+	md := report.GenerateMarkdownReport(reports, map[string]*model.ChangelogInfo{})
 	return os.WriteFile(output, []byte(md), 0644)
 }
 
-func writeMarkdownReportWithHeader(header string, reports []report.NpmDepReport, changelogs map[string]*model.ChangelogInfo, output string, appendMode bool) error {
-	md := "## " + header + "\n" + report.GenerateNpmMarkdownReport(reports, changelogs) + "\n"
+// writeMarkdownReportWithHeader writes a report section to output, followed by
+// any summary lines.
+func writeMarkdownReportWithHeader(header string, reports []model.Dependency, changelogs map[string]*model.ChangelogInfo, output string, appendMode bool, summary ...string) error {
+	md := "## " + header + "\n" + report.GenerateMarkdownReport(reports, changelogs) + "\n"
+	for _, line := range summary {
+		if line != "" {
+			md += "_" + line + "_\n\n"
+		}
+	}
 	flag := os.O_CREATE | os.O_WRONLY
 	if appendMode {
 		flag |= os.O_APPEND
@@ -176,75 +116,55 @@ var rootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("depflow: Scanning directory %s, will output to %s\n", dir, output)
 
-		lockPath, err := scanForNpmLockFile(dir)
+		detections, err := scan.Detect(dir)
 		if err != nil {
 			fmt.Printf("Error scanning for lock files: %v\n", err)
 			return
 		}
-		yarnPath, err := scanForYarnLockFile(dir)
-		if err != nil {
-			fmt.Printf("Error scanning for lock files: %v\n", err)
+		if len(detections) == 0 {
+			fmt.Println("No supported lock files found.")
 			return
 		}
 
 		wrote := false
-		if lockPath != "" {
-			fmt.Printf("Found lock file: %s\n", lockPath)
-			reports, changelogs, err := checkNpmDependencies(lockPath)
+		for _, d := range detections {
+			fmt.Printf("Found lock file: %s\n", d.Path)
+			fmt.Printf("Checking %s dependencies for updates...\n", d.Ecosystem.Name())
+			sections, changelogs, err := checkDependencies(d.Ecosystem, d.Path)
 			if err != nil {
-				fmt.Printf("Error checking npm dependencies: %v\n", err)
-				return
+				fmt.Printf("Error checking %s dependencies: %v\n", d.Ecosystem.Name(), err)
+				continue
 			}
-			fmt.Println("Checking npm dependencies for updates...")
-			err = writeMarkdownReportWithHeader("NPM (package-lock.json)", reports, changelogs, output, false)
-			if err != nil {
-				fmt.Printf("Error writing report to %s: %v\n", output, err)
-			} else {
-				fmt.Printf("Report written to %s\n", output)
+			for _, section := range sections {
+				err = writeMarkdownReportWithHeader(section.Title, section.Dependencies, changelogs, output, wrote, section.Summary)
+				if err != nil {
+					fmt.Printf("Error writing report to %s: %v\n", output, err)
+					break
+				}
 				wrote = true
 			}
-		}
-		if yarnPath != "" {
-			fmt.Printf("Found lock file: %s\n", yarnPath)
-			reports, changelogs, err := checkYarnDependencies(yarnPath)
-			if err != nil {
-				fmt.Printf("Error checking yarn dependencies: %v\n", err)
-				return
-			}
-			fmt.Println("Checking yarn dependencies for updates...")
-			err = writeMarkdownReportWithHeader("Yarn (yarn.lock)", reports, changelogs, output, wrote)
-			if err != nil {
-				fmt.Printf("Error writing report to %s: %v\n", output, err)
-			} else {
+			if err == nil {
 				fmt.Printf("Report written to %s\n", output)
 			}
 		}
-		goModPath := dir + "/go.mod"
-		if _, err := os.Stat(goModPath); err == nil {
-			fmt.Printf("Found lock file: %s\n", goModPath)
-			reports, changelogs, err := checkGoDependencies(dir, goModPath, check.GetGoModuleLatestVersions)
-			if err != nil {
-				fmt.Printf("Error checking Go dependencies: %v\n", err)
-				return
-			}
-			fmt.Println("Checking Go dependencies for updates...")
-			err = writeMarkdownReportWithHeader("Go (go.mod)", reports, changelogs, output, false)
-			if err != nil {
-				fmt.Printf("Error writing report to %s: %v\n", output, err)
-			} else {
-				fmt.Printf("Report written to %s\n", output)
-				wrote = true
-			}
-		}
-		if lockPath == "" && yarnPath == "" {
-			fmt.Println("No package-lock.json or yarn.lock found.")
-		}
 	},
 }
 
 func Execute() {
 	rootCmd.PersistentFlags().StringVar(&dir, "dir", ".", "Directory to scan for dependency files")
 	rootCmd.PersistentFlags().StringVar(&output, "output", "dependency-report.md", "Output Markdown report file")
+	rootCmd.PersistentFlags().StringVar(&check.NpmRegistryURL, "npm-registry-url", check.NpmRegistryURL, "Base URL of the npm registry")
+	rootCmd.PersistentFlags().StringVar(&check.NpmDistTag, "npm-tag", check.NpmDistTag, "npm dist-tag (release channel) to compare packages against")
+	rootCmd.PersistentFlags().StringToStringVar(&check.NpmPackageDistTags, "npm-package-tag", nil, "npm dist-tag to compare a package against, as package=tag (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&check.PyPIIndexURL, "pypi-index-url", check.PyPIIndexURL, "Base URL of the PyPI JSON API")
+	rootCmd.PersistentFlags().StringVar(&check.MavenRepositoryURL, "maven-repo-url", check.MavenRepositoryURL, "Base URL of the Maven repository")
+	rootCmd.PersistentFlags().StringVar(&check.CratesIndexURL, "crates-index-url", check.CratesIndexURL, "Base URL or directory of the crates.io sparse index")
+	rootCmd.PersistentFlags().StringVar(&check.RubyGemsURL, "rubygems-url", check.RubyGemsURL, "Base URL of the RubyGems API")
+	rootCmd.PersistentFlags().StringVar(&check.PackagistURL, "packagist-url", check.PackagistURL, "Base URL of the Composer repository (p2 metadata)")
+	rootCmd.PersistentFlags().StringVar(&check.NuGetServiceIndexURL, "nuget-service-index", check.NuGetServiceIndexURL, "URL of the NuGet v3 service index")
+	if g, ok := ecosystem.Lookup("go").(*ecosystem.Go); ok {
+		rootCmd.PersistentFlags().BoolVar(&g.IncludeIndirect, "include-indirect", false, "List indirect Go requirements in the report")
+	}
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -1,13 +1,14 @@
 package cmd
 
 import (
-	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/cyber-kamil/depflow/internal/ecosystem"
 	"github.com/cyber-kamil/depflow/internal/model"
-	"github.com/cyber-kamil/depflow/internal/report"
+	"github.com/cyber-kamil/depflow/internal/version"
 )
 
 func TestWriteMarkdownReportWithHeader(t *testing.T) {
@@ -16,9 +17,9 @@ func TestWriteMarkdownReportWithHeader(t *testing.T) {
 		t.Fatalf("failed to create temp file: %v", err)
 	}
 	defer os.Remove(tmp.Name())
-	reports := []report.NpmDepReport{
-		{Name: "express", Current: "4.18.2", Latest: "4.18.2", Outdated: false},
-		{Name: "lodash", Current: "4.17.20", Latest: "4.17.21", Outdated: true},
+	reports := []model.Dependency{
+		{Name: "express", Version: "4.18.2", Latest: "4.18.2", Outdated: false},
+		{Name: "lodash", Version: "4.17.20", Latest: "4.17.21", Outdated: true},
 	}
 	err = writeMarkdownReportWithHeader("NPM", reports, map[string]*model.ChangelogInfo{}, tmp.Name(), false)
 	if err != nil {
@@ -36,73 +37,117 @@ func TestWriteMarkdownReportWithHeader(t *testing.T) {
 	}
 }
 
-func TestScanForNpmLockFile(t *testing.T) {
+func TestCheckGoDependencies(t *testing.T) {
 	dir := t.TempDir()
-	f, err := os.Create(dir + "/package-lock.json")
-	if err != nil {
-		t.Fatalf("failed to create lock file: %v", err)
+	gomod := `module github.com/example/project
+
+go 1.20
+
+require (
+	github.com/stretchr/testify v1.8.0
+)
+`
+	gomodPath := dir + "/go.mod"
+	if err := os.WriteFile(gomodPath, []byte(gomod), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
 	}
-	f.Close()
-	files, _ := ioutil.ReadDir(dir)
-	if len(files) == 0 {
-		t.Fatalf("no files in temp dir: %s", dir)
+
+	mockVersionChecker := func(dir string) map[string]string {
+		return map[string]string{"github.com/stretchr/testify": "v1.9.0"}
 	}
-	path, err := scanForNpmLockFile(dir)
+
+	goEco := &ecosystem.Go{VersionChecker: func(dir string) (map[string]string, error) {
+		return mockVersionChecker(dir), nil
+	}}
+	sections, changelogs, err := checkDependencies(goEco, gomodPath)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if !strings.HasSuffix(path, "package-lock.json") {
-		t.Errorf("expected package-lock.json, got %s (dir: %s, files: %v)", path, dir, files)
+	if len(sections) != 1 || len(sections[0].Dependencies) == 0 || !sections[0].Dependencies[0].Outdated {
+		t.Errorf("expected outdated module, got %v", sections)
 	}
+	_ = changelogs // not checked in this test
 }
 
-func TestScanForYarnLockFile(t *testing.T) {
-	dir := t.TempDir()
-	f, err := os.Create(dir + "/yarn.lock")
-	if err != nil {
-		t.Fatalf("failed to create lock file: %v", err)
-	}
-	f.Close()
-	files, _ := ioutil.ReadDir(dir)
-	if len(files) == 0 {
-		t.Fatalf("no files in temp dir: %s", dir)
-	}
-	path, err := scanForYarnLockFile(dir)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if !strings.HasSuffix(path, "yarn.lock") {
-		t.Errorf("expected yarn.lock, got %s (dir: %s, files: %v)", path, dir, files)
-	}
+// changelogCounter is a Go ecosystem recording changelog lookups.
+type changelogCounter struct {
+	*ecosystem.Go
+	fetched []string
 }
 
-func TestCheckGoDependencies(t *testing.T) {
-	dir := t.TempDir()
+func (c *changelogCounter) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	c.fetched = append(c.fetched, name)
+	return &model.ChangelogInfo{Dependency: name}, nil
+}
+
+func TestCheckDependencies_ChangelogsForListedOnly(t *testing.T) {
+	gomodPath := filepath.Join(t.TempDir(), "go.mod")
 	gomod := `module github.com/example/project
 
 go 1.20
 
 require (
 	github.com/stretchr/testify v1.8.0
+	github.com/davecgh/go-spew v1.1.0 // indirect
 )
 `
-	gomodPath := dir + "/go.mod"
 	if err := os.WriteFile(gomodPath, []byte(gomod), 0644); err != nil {
 		t.Fatalf("failed to write go.mod: %v", err)
 	}
+	eco := &changelogCounter{Go: &ecosystem.Go{VersionChecker: func(dir string) (map[string]string, error) {
+		return map[string]string{"github.com/stretchr/testify": "v1.9.0", "github.com/davecgh/go-spew": "v1.1.1"}, nil
+	}}}
 
-	mockVersionChecker := func(dir string) map[string]string {
-		return map[string]string{"github.com/stretchr/testify": "v1.9.0"}
+	_, changelogs, err := checkDependencies(eco, gomodPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(eco.fetched, ",") != "github.com/stretchr/testify" || len(changelogs) != 1 {
+		t.Errorf("expected a changelog for the listed module only, fetched %v", eco.fetched)
 	}
 
-	reports, changelogs, err := checkGoDependencies(dir, gomodPath, func(dir string) (map[string]string, error) {
-		return mockVersionChecker(dir), nil
-	})
-	if err != nil {
+	eco.fetched = nil
+	eco.IncludeIndirect = true
+	if _, _, err := checkDependencies(eco, gomodPath); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(reports) == 0 || !reports[0].Outdated {
-		t.Errorf("expected outdated module, got %v", reports)
+	if len(eco.fetched) != 2 {
+		t.Errorf("expected changelogs for indirect modules when they are listed, fetched %v", eco.fetched)
+	}
+}
+
+func TestWriteMarkdownReportWithHeader_Summary(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.md")
+	reports := []model.Dependency{{Name: "github.com/stretchr/testify", Version: "v1.8.0", Latest: "v1.9.0", Outdated: true}}
+	if err := writeMarkdownReportWithHeader("Go (go.mod)", reports, nil, output, false, "Outdated indirect modules: 2"); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(data), "_Outdated indirect modules: 2_") {
+		t.Errorf("report missing summary line:\n%s", data)
+	}
+}
+
+func TestClassifyUpdate(t *testing.T) {
+	cases := []struct {
+		dep      model.Dependency
+		outdated bool
+		update   string
+	}{
+		{model.Dependency{Version: "4.17.20", Latest: "4.17.21"}, true, "patch"},
+		{model.Dependency{Version: "5.0.0-beta.1", Latest: "4.17.21"}, false, ""},
+		{model.Dependency{Version: "4.17.21", Latest: "4.17.21"}, false, ""},
+		{model.Dependency{Version: "github:lodash/lodash", Latest: "4.17.21"}, true, ""},
+		{model.Dependency{Range: ">=4", Latest: "4.17.21"}, false, ""},
+	}
+	for _, c := range cases {
+		dep := c.dep
+		classifyUpdate(version.SemVer, &dep)
+		if dep.Outdated != c.outdated || dep.UpdateType != c.update {
+			t.Errorf("%+v: got outdated %v, update %q", c.dep, dep.Outdated, dep.UpdateType)
+		}
 	}
-	_ = changelogs // not checked in this test
 }
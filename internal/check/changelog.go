@@ -12,11 +12,16 @@ import (
 	"github.com/cyber-kamil/depflow/internal/model"
 )
 
-// FetchChangelogInfo tries to find and summarize the changelog for a dependency.
-// For now, only npm is supported. This function can be extended for other ecosystems.
+// FetchChangelogInfo tries to find and summarize the changelog for an npm package.
+// Other ecosystems look up the repository themselves and use ChangelogInfoFromRepo.
 func FetchChangelogInfo(depName, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	return FetchChangelogInfoWithBase(depName, currentVersion, latestVersion, NpmRegistryURL)
+}
+
+// FetchChangelogInfoWithBase is FetchChangelogInfo against the npm registry at base.
+func FetchChangelogInfoWithBase(depName, currentVersion, latestVersion, base string) (*model.ChangelogInfo, error) {
 	// Step 1: Fetch npm package metadata
-	url := fmt.Sprintf("https://registry.npmjs.org/%s", depName)
+	url := fmt.Sprintf("%s%s", base, depName)
 	resp, err := http.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch npm info for %s: %w", depName, err)
@@ -35,14 +40,20 @@ func FetchChangelogInfo(depName, currentVersion, latestVersion string) (*model.C
 		return nil, fmt.Errorf("failed to decode npm registry response for %s: %w", depName, err)
 	}
 
-	repoURL := data.Repository.URL
+	return ChangelogInfoFromRepo(depName, data.Repository.URL, currentVersion, latestVersion), nil
+}
+
+// ChangelogInfoFromRepo builds changelog information from a source repository URL.
+// For GitHub repositories the CHANGELOG.md is fetched and scanned for breaking changes
+// between currentVersion and latestVersion.
+func ChangelogInfoFromRepo(depName, repoURL, currentVersion, latestVersion string) *model.ChangelogInfo {
 	if repoURL == "" {
 		return &model.ChangelogInfo{
 			Dependency:   depName,
 			RepoURL:      "",
 			ChangelogURL: "",
 			Highlights:   nil,
-		}, nil
+		}
 	}
 
 	// Normalize GitHub URLs (remove git+, .git, etc.)
@@ -88,7 +99,7 @@ func FetchChangelogInfo(depName, currentVersion, latestVersion string) (*model.C
 		RepoURL:      repoURL,
 		ChangelogURL: changelogURL,
 		Highlights:   highlights,
-	}, nil
+	}
 }
 
 // extractChangelogSection extracts the changelog section(s) between current and latest version headings.
@@ -0,0 +1,29 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchChangelogInfo_RegistryBase(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/left-pad" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"name":"left-pad","repository":{"type":"git","url":""}}`))
+	}))
+	defer ts.Close()
+
+	info, err := FetchChangelogInfoWithBase("left-pad", "1.0.0", "1.3.0", ts.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Dependency != "left-pad" || info.RepoURL != "" {
+		t.Errorf("unexpected changelog info: %+v", info)
+	}
+	if _, err := FetchChangelogInfoWithBase("missing", "1.0.0", "1.3.0", ts.URL+"/"); err == nil {
+		t.Error("expected an error for a package the registry does not have")
+	}
+}
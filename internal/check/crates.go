@@ -0,0 +1,153 @@
+package check
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"golang.org/x/mod/semver"
+)
+
+// CratesIndexURL is the crates.io sparse index. It may also be a local directory
+// (or file:// URL) laid out like the index.
+var CratesIndexURL = "https://index.crates.io/"
+
+// CratesAPIURL is the crates.io web API used to find crate repositories.
+var CratesAPIURL = "https://crates.io/api/v1/"
+
+// CrateVersion is one line of a crate's index file.
+type CrateVersion struct {
+	Name     string `json:"name"`
+	Version  string `json:"vers"`
+	Checksum string `json:"cksum"`
+	Yanked   bool   `json:"yanked"`
+}
+
+// GetCrateVersions reads all published versions of a crate from CratesIndexURL.
+func GetCrateVersions(name string) ([]CrateVersion, error) {
+	return GetCrateVersionsWithBase(name, CratesIndexURL)
+}
+
+// GetCrateVersionsWithBase reads all published versions of a crate from the sparse
+// index at base, which is either an HTTP(S) URL or a local directory.
+func GetCrateVersionsWithBase(name, base string) ([]CrateVersion, error) {
+	data, err := readCrateIndexFile(base, CrateIndexPath(name))
+	if err != nil {
+		return nil, err
+	}
+	versions := []CrateVersion{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var v CrateVersion
+		if err := json.Unmarshal(line, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode index entry for %s: %w", name, err)
+		}
+		versions = append(versions, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read index file for %s: %w", name, err)
+	}
+	return versions, nil
+}
+
+// LatestCrateVersion returns the newest version that is not yanked. Pre-releases
+// are only considered when no stable version exists.
+func LatestCrateVersion(versions []CrateVersion) string {
+	latest, latestPre := "", ""
+	for _, v := range versions {
+		sv := "v" + v.Version
+		if v.Yanked || !semver.IsValid(sv) {
+			continue
+		}
+		if semver.Prerelease(sv) != "" {
+			if latestPre == "" || semver.Compare(sv, "v"+latestPre) > 0 {
+				latestPre = v.Version
+			}
+			continue
+		}
+		if latest == "" || semver.Compare(sv, "v"+latest) > 0 {
+			latest = v.Version
+		}
+	}
+	if latest == "" {
+		return latestPre
+	}
+	return latest
+}
+
+// CrateIndexPath returns the path of a crate's file in the index, e.g.
+// "se/rd/serde", "3/s/syn" or "1/a".
+func CrateIndexPath(name string) string {
+	name = strings.ToLower(name)
+	switch len(name) {
+	case 1:
+		return "1/" + name
+	case 2:
+		return "2/" + name
+	case 3:
+		return "3/" + name[:1] + "/" + name
+	default:
+		return name[:2] + "/" + name[2:4] + "/" + name
+	}
+}
+
+func readCrateIndexFile(base, rel string) ([]byte, error) {
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		dir := strings.TrimPrefix(base, "file://")
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read crate index file %s: %w", rel, err)
+		}
+		return data, nil
+	}
+	url := strings.TrimSuffix(base, "/") + "/" + rel
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crate index file %s: %w", rel, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("crate index returned status %d for %s", resp.StatusCode, rel)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// FetchCrateChangelogInfo looks up the repository of a crate on crates.io and
+// summarizes its changelog between currentVersion and latestVersion.
+func FetchCrateChangelogInfo(name, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	req, err := http.NewRequest("GET", strings.TrimSuffix(CratesAPIURL, "/")+"/crates/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	// crates.io rejects requests without a User-Agent.
+	req.Header.Set("User-Agent", "depflow (https://github.com/cyber-kamil/depflow)")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crate info for %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("crates.io returned status %d for %s", resp.StatusCode, name)
+	}
+	var data struct {
+		Crate struct {
+			Repository string `json:"repository"`
+		} `json:"crate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode crate info for %s: %w", name, err)
+	}
+	return ChangelogInfoFromRepo(name, strings.TrimSuffix(data.Crate.Repository, "/"), currentVersion, latestVersion), nil
+}
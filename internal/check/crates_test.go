@@ -0,0 +1,66 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const serdeIndex = `{"name":"serde","vers":"1.0.192","deps":[],"cksum":"a","features":{},"yanked":false}
+{"name":"serde","vers":"1.0.193","deps":[],"cksum":"b","features":{},"yanked":false}
+{"name":"serde","vers":"1.0.194","deps":[],"cksum":"c","features":{},"yanked":true}
+{"name":"serde","vers":"2.0.0-alpha.1","deps":[],"cksum":"d","features":{},"yanked":false}
+`
+
+func TestCrateIndexPath(t *testing.T) {
+	cases := map[string]string{"a": "1/a", "cc": "2/cc", "syn": "3/s/syn", "Serde": "se/rd/serde"}
+	for name, want := range cases {
+		if got := CrateIndexPath(name); got != want {
+			t.Errorf("CrateIndexPath(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGetCrateVersions_HTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/se/rd/serde" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(serdeIndex))
+	}))
+	defer ts.Close()
+
+	versions, err := GetCrateVersionsWithBase("serde", ts.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 4 || !versions[2].Yanked {
+		t.Fatalf("unexpected versions: %+v", versions)
+	}
+	if latest := LatestCrateVersion(versions); latest != "1.0.193" {
+		t.Errorf("expected 1.0.193, got %s", latest)
+	}
+}
+
+func TestGetCrateVersions_LocalDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "se", "rd"), 0755); err != nil {
+		t.Fatalf("failed to create index dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "se", "rd", "serde"), []byte(serdeIndex), 0644); err != nil {
+		t.Fatalf("failed to write index file: %v", err)
+	}
+	versions, err := GetCrateVersionsWithBase("serde", "file://"+dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 4 {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+	if _, err := GetCrateVersionsWithBase("missing", dir); err == nil {
+		t.Error("expected error for missing crate, got nil")
+	}
+}
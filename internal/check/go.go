@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"strings"
 )
 
 type GoModuleVersion struct {
@@ -14,18 +15,56 @@ type GoModuleVersion struct {
 	Update  *struct {
 		Version string
 	} `json:"Update"`
+	Replace   *GoModuleVersion `json:"Replace"`
+	Retracted []string         `json:"Retracted"`
 }
 
 // GetGoModuleLatestVersions runs 'go list -m -u -json all' in the given directory and returns a map of module names to their latest versions (if available).
+// Updates of module replacement targets are included under the target's path.
 func GetGoModuleLatestVersions(dir string) (map[string]string, error) {
-	cmd := exec.Command("go", "list", "-m", "-u", "-json", "all")
+	mods, err := listGoModules(dir, "-u")
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]string)
+	for _, mod := range mods {
+		if mod.Update != nil && mod.Update.Version != "" {
+			latest[mod.Path] = mod.Update.Version
+		}
+		if r := mod.Replace; r != nil && r.Version != "" && r.Update != nil && r.Update.Version != "" {
+			latest[r.Path] = r.Update.Version
+		}
+	}
+	return latest, nil
+}
+
+// GetGoRetractedVersions runs 'go list -m -retracted -json all' in the given directory and
+// returns the modules whose selected version has been retracted by its author, with the
+// retraction rationale.
+func GetGoRetractedVersions(dir string) (map[string]string, error) {
+	mods, err := listGoModules(dir, "-retracted")
+	if err != nil {
+		return nil, err
+	}
+	retracted := make(map[string]string)
+	for _, mod := range mods {
+		if len(mod.Retracted) > 0 {
+			retracted[mod.Path] = strings.Join(mod.Retracted, "; ")
+		}
+	}
+	return retracted, nil
+}
+
+func listGoModules(dir string, flags ...string) ([]GoModuleVersion, error) {
+	args := append([]string{"list", "-m"}, flags...)
+	cmd := exec.Command("go", append(args, "-json", "all")...)
 	cmd.Dir = dir
 	out, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run 'go list': %w", err)
 	}
 
-	latest := make(map[string]string)
+	mods := []GoModuleVersion{}
 	scanner := bufio.NewScanner(bytes.NewReader(out))
 	scanner.Split(splitJSONObjects)
 	for scanner.Scan() {
@@ -33,11 +72,9 @@ func GetGoModuleLatestVersions(dir string) (map[string]string, error) {
 		if err := json.Unmarshal(scanner.Bytes(), &mod); err != nil {
 			continue // skip malformed
 		}
-		if mod.Update != nil && mod.Update.Version != "" {
-			latest[mod.Path] = mod.Update.Version
-		}
+		mods = append(mods, mod)
 	}
-	return latest, nil
+	return mods, nil
 }
 
 // splitJSONObjects is a bufio.SplitFunc that splits a stream of concatenated JSON objects.
@@ -0,0 +1,347 @@
+package check
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyber-kamil/depflow/internal/parse"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// DefaultGOPROXY is the proxy list used when GOPROXY is not set.
+const DefaultGOPROXY = "https://proxy.golang.org,direct"
+
+// maxMajorProbes bounds the major version paths QueryMajorSuccessor tries.
+const maxMajorProbes = 20
+
+// errGoProxyNotFound marks 404 and 410 responses, which let a lookup fall
+// through to the next proxy in a comma-separated GOPROXY list.
+var errGoProxyNotFound = errors.New("not found")
+
+// GoProxyUnavailableError reports a module the configured proxies cannot
+// serve, which the go command would fetch from version control, if at all.
+type GoProxyUnavailableError struct {
+	Module string
+	Reason string // e.g. "matches GONOPROXY"
+}
+
+func (e *GoProxyUnavailableError) Error() string {
+	return e.Module + ": " + e.Reason
+}
+
+// GoProxy is a client for the Go module proxy protocol. GOPROXY and GONOPROXY
+// have the meaning of the environment variables of the same name; file://
+// proxies are read from the local file system. "direct" entries cannot be
+// served, as depflow does not fetch modules from version control.
+type GoProxy struct {
+	GOPROXY   string
+	GONOPROXY string
+}
+
+// NewGoProxyFromEnv returns a client configured from GOPROXY and GONOPROXY,
+// falling back to GOPRIVATE for the latter like the go command does.
+func NewGoProxyFromEnv() *GoProxy {
+	p := &GoProxy{GOPROXY: os.Getenv("GOPROXY"), GONOPROXY: os.Getenv("GONOPROXY")}
+	if p.GOPROXY == "" {
+		p.GOPROXY = DefaultGOPROXY
+	}
+	if p.GONOPROXY == "" {
+		p.GONOPROXY = os.Getenv("GOPRIVATE")
+	}
+	return p
+}
+
+// GoModuleInfo is the JSON served by the .info and @latest endpoints.
+type GoModuleInfo struct {
+	Version string
+	Time    time.Time
+}
+
+// Versions returns the tagged versions listed by <module>/@v/list. A module
+// unknown to every proxy has no versions.
+func (p *GoProxy) Versions(modPath string) ([]string, error) {
+	data, err := p.fetch(modPath, "@v/list")
+	if errors.Is(err, errGoProxyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// Latest returns <module>/@latest, which also covers modules without tags.
+func (p *GoProxy) Latest(modPath string) (*GoModuleInfo, error) {
+	return p.info(modPath, "@latest")
+}
+
+// Info returns <module>/@v/<version>.info.
+func (p *GoProxy) Info(modPath, version string) (*GoModuleInfo, error) {
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return p.info(modPath, "@v/"+escaped+".info")
+}
+
+func (p *GoProxy) info(modPath, endpoint string) (*GoModuleInfo, error) {
+	data, err := p.fetch(modPath, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var info GoModuleInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode %s/%s: %w", modPath, endpoint, err)
+	}
+	return &info, nil
+}
+
+// GoMod returns <module>/@v/<version>.mod.
+func (p *GoProxy) GoMod(modPath, version string) ([]byte, error) {
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return p.fetch(modPath, "@v/"+escaped+".mod")
+}
+
+// GoModuleQuery is the newest usable version of a module.
+type GoModuleQuery struct {
+	Version string
+	// GoMod is the go.mod of the newest published version, whose retract
+	// directives apply to every version of the module. It is nil if the
+	// proxy could not serve it.
+	GoMod *parse.GoModFile
+}
+
+// GoModuleListing is what the proxy serves about a module's versions,
+// independent of the go.mod requiring it.
+type GoModuleListing struct {
+	Path string
+	// Versions are the valid versions listed by @v/list, in semver order.
+	Versions []string
+	// GoMod is the go.mod of the newest listed version, or of @latest when no
+	// version is listed. It is nil if the proxy could not serve it.
+	GoMod *parse.GoModFile
+	// Latest is the @latest version, only looked up when no version is listed.
+	Latest string
+}
+
+// ListModule fetches the version list of a module and the go.mod carrying its
+// retractions. Modules without tagged versions are looked up with @latest.
+func (p *GoProxy) ListModule(modPath string) (*GoModuleListing, error) {
+	listed, err := p.Versions(modPath)
+	if err != nil {
+		return nil, err
+	}
+	l := &GoModuleListing{Path: modPath}
+	for _, v := range listed {
+		if semver.IsValid(v) {
+			l.Versions = append(l.Versions, v)
+		}
+	}
+	if len(l.Versions) == 0 {
+		info, err := p.Latest(modPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find versions of %s: %w", modPath, err)
+		}
+		l.Latest = info.Version
+		l.GoMod = p.parseGoMod(modPath, info.Version)
+		return l, nil
+	}
+	semver.Sort(l.Versions)
+	l.GoMod = p.parseGoMod(modPath, l.Versions[len(l.Versions)-1])
+	return l, nil
+}
+
+// Select picks the version 'go get <module>@latest' would: the highest
+// release that is neither retracted nor excluded, else the highest
+// prerelease, else @latest (usually a pseudo-version). +incompatible versions
+// are only chosen when no compatible release exists or when current, the
+// version in use, is itself +incompatible.
+func (l *GoModuleListing) Select(current string, excluded func(version string) bool) (*GoModuleQuery, error) {
+	q := &GoModuleQuery{GoMod: l.GoMod}
+	if len(l.Versions) == 0 {
+		q.Version = l.Latest
+		return q, nil
+	}
+	var release, compatible, prerelease string
+	for _, v := range l.Versions {
+		if excluded != nil && excluded(v) {
+			continue
+		}
+		if q.GoMod != nil {
+			if _, retracted := q.GoMod.Retracted(v); retracted {
+				continue
+			}
+		}
+		switch {
+		case semver.Prerelease(v) != "":
+			prerelease = v
+		case semver.Build(v) == "+incompatible":
+			release = v
+		default:
+			release, compatible = v, v
+		}
+	}
+	if semver.Build(current) == "+incompatible" {
+		compatible = ""
+	}
+	q.Version = firstNonEmpty(compatible, release, prerelease)
+	if q.Version == "" {
+		return nil, fmt.Errorf("every version of %s is retracted or excluded", l.Path)
+	}
+	return q, nil
+}
+
+// QueryLatest lists a module and selects its latest version, see
+// GoModuleListing.Select.
+func (p *GoProxy) QueryLatest(modPath, current string, excluded func(version string) bool) (*GoModuleQuery, error) {
+	l, err := p.ListModule(modPath)
+	if err != nil {
+		return nil, err
+	}
+	return l.Select(current, excluded)
+}
+
+// QueryMajorSuccessor looks for modPath at higher major version paths, such
+// as example.com/mod/v3 for example.com/mod/v2 or gopkg.in/yaml.v3 for
+// gopkg.in/yaml.v2, and returns the highest one found with its newest version.
+// Paths are probed in order until one cannot be queried, starting above the
+// major of a +incompatible current version. It returns "" and nil when there
+// is no successor.
+func (p *GoProxy) QueryMajorSuccessor(modPath, current string) (string, *GoModuleQuery) {
+	prefix, pathMajor, ok := module.SplitPathVersion(modPath)
+	if !ok {
+		return "", nil
+	}
+	sep, major := "/v", 1
+	if strings.HasPrefix(modPath, "gopkg.in/") {
+		sep = ".v"
+	}
+	if pathMajor != "" {
+		major, _ = strconv.Atoi(pathMajor[2:])
+	}
+	next := major + 1
+	if semver.Build(current) == "+incompatible" {
+		if n, err := strconv.Atoi(strings.TrimPrefix(semver.Major(current), "v")); err == nil && n+1 > next {
+			next = n + 1
+		}
+	}
+	if next < 2 && sep == "/v" {
+		next = 2 // /v0 and /v1 are not valid module path suffixes
+	}
+
+	var path string
+	var latest *GoModuleQuery
+	for last := next + maxMajorProbes; next < last; next++ {
+		candidate := prefix + sep + strconv.Itoa(next)
+		q, err := p.QueryLatest(candidate, "", nil)
+		if err != nil {
+			break
+		}
+		path, latest = candidate, q
+	}
+	return path, latest
+}
+
+func (p *GoProxy) parseGoMod(modPath, version string) *parse.GoModFile {
+	data, err := p.GoMod(modPath, version)
+	if err != nil {
+		return nil
+	}
+	f, err := parse.ParseGoModData(modPath+"@"+version+"/go.mod", data)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// fetch requests <proxy>/<escaped module>/<endpoint> from each GOPROXY entry in
+// turn. After a comma only "not found" falls through to the next entry; after
+// a pipe any error does.
+func (p *GoProxy) fetch(modPath, endpoint string) ([]byte, error) {
+	if module.MatchPrefixPatterns(p.GONOPROXY, modPath) {
+		return nil, &GoProxyUnavailableError{Module: modPath, Reason: "matches GONOPROXY"}
+	}
+	escaped, err := module.EscapePath(modPath)
+	if err != nil {
+		return nil, err
+	}
+	proxies := p.GOPROXY
+	if proxies == "" {
+		proxies = DefaultGOPROXY
+	}
+	lastErr := fmt.Errorf("no proxy configured for %s", modPath)
+	for proxies != "" {
+		entry := proxies
+		anyError := false
+		if i := strings.IndexAny(proxies, ",|"); i >= 0 {
+			entry, anyError, proxies = proxies[:i], proxies[i] == '|', proxies[i+1:]
+		} else {
+			proxies = ""
+		}
+		switch entry = strings.TrimSpace(entry); entry {
+		case "":
+			continue
+		case "off":
+			return nil, &GoProxyUnavailableError{Module: modPath, Reason: "module lookup disabled by GOPROXY=off"}
+		case "direct":
+			return nil, &GoProxyUnavailableError{Module: modPath, Reason: "not available from a proxy (GOPROXY=direct)"}
+		}
+		data, err := goProxyGet(strings.TrimSuffix(entry, "/") + "/" + escaped + "/" + endpoint)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+		if !anyError && !errors.Is(err, errGoProxyNotFound) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+func goProxyGet(rawURL string) ([]byte, error) {
+	if strings.HasPrefix(rawURL, "file://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %s: %w", rawURL, err)
+		}
+		data, err := os.ReadFile(filepath.FromSlash(u.Path))
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", rawURL, errGoProxyNotFound)
+		}
+		return data, err
+	}
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case 200:
+		return io.ReadAll(resp.Body)
+	case 404, 410:
+		return nil, fmt.Errorf("%s: %w", rawURL, errGoProxyNotFound)
+	}
+	return nil, fmt.Errorf("proxy returned status %d for %s", resp.StatusCode, rawURL)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package check
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeGoProxy lays out a file:// module proxy from files keyed by their
+// path below the proxy root, e.g. "github.com/!azure/sdk/@v/list".
+func writeGoProxy(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestGoProxyQueryLatest_FileProxy(t *testing.T) {
+	dir := writeGoProxy(t, map[string]string{
+		"github.com/!burnt!sushi/toml/@v/list":            "v1.2.0\nv1.3.0\nv1.4.0\nv1.5.0-rc.1\n",
+		"github.com/!burnt!sushi/toml/@v/v1.5.0-rc.1.mod": "module github.com/BurntSushi/toml\n\nretract v1.4.0 // broken decoder\n",
+		"example.com/untagged/@latest":                    "{\"Version\":\"v0.0.0-20240101120000-abcdef123456\",\"Time\":\"2024-01-01T12:00:00Z\"}",
+	})
+	p := &GoProxy{GOPROXY: "file://" + filepath.ToSlash(dir)}
+
+	q, err := p.QueryLatest("github.com/BurntSushi/toml", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Version != "v1.3.0" {
+		t.Errorf("expected v1.3.0 (v1.4.0 retracted, v1.5.0-rc.1 prerelease), got %s", q.Version)
+	}
+	if rationale, ok := q.GoMod.Retracted("v1.4.0"); !ok || rationale != "broken decoder" {
+		t.Errorf("expected v1.4.0 to be retracted, got %q %v", rationale, ok)
+	}
+
+	q, err = p.QueryLatest("github.com/BurntSushi/toml", "", func(v string) bool { return v == "v1.3.0" })
+	if err != nil || q.Version != "v1.2.0" {
+		t.Errorf("expected excluded v1.3.0 to be skipped, got %+v (err %v)", q, err)
+	}
+
+	q, err = p.QueryLatest("example.com/untagged", "", nil)
+	if err != nil || q.Version != "v0.0.0-20240101120000-abcdef123456" {
+		t.Errorf("expected @latest pseudo-version, got %+v (err %v)", q, err)
+	}
+}
+
+func TestGoProxyQueryLatest_Incompatible(t *testing.T) {
+	dir := writeGoProxy(t, map[string]string{
+		"github.com/docker/docker/@v/list": "v1.13.1\nv20.10.0+incompatible\nv24.0.7+incompatible\n",
+		"example.com/legacy/@v/list":       "v2.0.0+incompatible\nv3.1.0+incompatible\n",
+	})
+	p := &GoProxy{GOPROXY: "file://" + filepath.ToSlash(dir)}
+	if q, err := p.QueryLatest("github.com/docker/docker", "v1.13.1", nil); err != nil || q.Version != "v1.13.1" {
+		t.Errorf("expected compatible release to win, got %+v (err %v)", q, err)
+	}
+	if q, err := p.QueryLatest("github.com/docker/docker", "v20.10.0+incompatible", nil); err != nil || q.Version != "v24.0.7+incompatible" {
+		t.Errorf("expected highest +incompatible release for a +incompatible current version, got %+v (err %v)", q, err)
+	}
+	if q, err := p.QueryLatest("example.com/legacy", "", nil); err != nil || q.Version != "v3.1.0+incompatible" {
+		t.Errorf("expected highest +incompatible release, got %+v (err %v)", q, err)
+	}
+}
+
+func TestGoProxyFetch_Fallthrough(t *testing.T) {
+	var hits []string
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, "failing")
+		w.WriteHeader(500)
+	}))
+	defer failing.Close()
+	missing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, "missing")
+		w.WriteHeader(404)
+	}))
+	defer missing.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, "good")
+		if r.URL.Path != "/golang.org/x/mod/@v/list" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte("v0.17.0\nv0.18.0\n"))
+	}))
+	defer good.Close()
+
+	p := &GoProxy{GOPROXY: missing.URL + "," + failing.URL + "|" + good.URL}
+	versions, err := p.Versions("golang.org/x/mod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || strings.Join(hits, ",") != "missing,failing,good" {
+		t.Errorf("unexpected versions %v after hits %v", versions, hits)
+	}
+
+	hits = nil
+	p = &GoProxy{GOPROXY: failing.URL + "," + good.URL}
+	if _, err := p.Versions("golang.org/x/mod"); err == nil || len(hits) != 1 {
+		t.Errorf("expected a server error to stop at a comma, got err %v after hits %v", err, hits)
+	}
+}
+
+func TestGoProxyFetch_Restrictions(t *testing.T) {
+	dir := writeGoProxy(t, map[string]string{"corp.example.com/lib/@v/list": "v1.0.0\n"})
+	proxy := "file://" + filepath.ToSlash(dir)
+
+	cases := []struct {
+		proxy  *GoProxy
+		module string
+		reason string
+	}{
+		{&GoProxy{GOPROXY: proxy, GONOPROXY: "*.example.com"}, "corp.example.com/lib", "matches GONOPROXY"},
+		{&GoProxy{GOPROXY: "off"}, "corp.example.com/lib", "module lookup disabled by GOPROXY=off"},
+		{&GoProxy{GOPROXY: proxy + ",direct"}, "example.com/unknown", "not available from a proxy (GOPROXY=direct)"},
+	}
+	for _, c := range cases {
+		_, err := c.proxy.Latest(c.module)
+		var unavailable *GoProxyUnavailableError
+		if !errors.As(err, &unavailable) || unavailable.Reason != c.reason {
+			t.Errorf("GOPROXY=%s %s: expected %q, got %v", c.proxy.GOPROXY, c.module, c.reason, err)
+		}
+	}
+}
+
+func TestNewGoProxyFromEnv(t *testing.T) {
+	t.Setenv("GOPROXY", "")
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GOPRIVATE", "corp.example.com")
+	p := NewGoProxyFromEnv()
+	if p.GOPROXY != DefaultGOPROXY || p.GONOPROXY != "corp.example.com" {
+		t.Errorf("unexpected proxy settings: %+v", p)
+	}
+}
+
+func TestGoProxyQueryMajorSuccessor(t *testing.T) {
+	dir := writeGoProxy(t, map[string]string{
+		"example.com/mod/@v/list":              "v1.0.0\n",
+		"example.com/mod/v2/@v/list":           "v2.0.0\nv2.1.0\n",
+		"example.com/mod/v3/@v/list":           "v3.0.0\nv3.2.1\n",
+		"example.com/mod/v5/@v/list":           "v5.0.0\n",
+		"gopkg.in/yaml.v3/@v/list":             "v3.0.1\n",
+		"github.com/go-redis/redis/v6/@v/list": "v6.15.9\n",
+		"github.com/go-redis/redis/v7/@v/list": "v7.4.1\n",
+		"github.com/go-redis/redis/v8/@v/list": "v8.11.5\n",
+	})
+	p := &GoProxy{GOPROXY: "file://" + filepath.ToSlash(dir)}
+
+	cases := []struct {
+		path, current string
+		want, latest  string
+	}{
+		{"example.com/mod", "v1.0.0", "example.com/mod/v3", "v3.2.1"},
+		{"example.com/mod/v2", "v2.0.0", "example.com/mod/v3", "v3.2.1"},
+		{"example.com/mod/v3", "v3.0.0", "", ""},
+		{"gopkg.in/yaml.v2", "v2.4.0", "gopkg.in/yaml.v3", "v3.0.1"},
+		{"github.com/go-redis/redis", "v6.15.9+incompatible", "github.com/go-redis/redis/v8", "v8.11.5"},
+		{"example.com/other", "v1.2.0", "", ""},
+	}
+	for _, c := range cases {
+		path, q := p.QueryMajorSuccessor(c.path, c.current)
+		latest := ""
+		if q != nil {
+			latest = q.Version
+		}
+		if path != c.want || latest != c.latest {
+			t.Errorf("QueryMajorSuccessor(%s, %s) = %q %q, want %q %q", c.path, c.current, path, latest, c.want, c.latest)
+		}
+	}
+}
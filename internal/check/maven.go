@@ -0,0 +1,118 @@
+package check
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/version"
+)
+
+// MavenRepositoryURL is the base URL of the Maven repository queried for
+// maven-metadata.xml files.
+var MavenRepositoryURL = "https://repo1.maven.org/maven2/"
+
+// MavenMetadata is the artifact-level maven-metadata.xml document.
+type MavenMetadata struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Versioning struct {
+		Latest   string   `xml:"latest"`
+		Release  string   `xml:"release"`
+		Versions []string `xml:"versions>version"`
+	} `xml:"versioning"`
+}
+
+// GetMavenLatestVersion queries MavenRepositoryURL for the latest release of an artifact.
+func GetMavenLatestVersion(groupID, artifactID string) (string, error) {
+	return GetMavenLatestVersionWithBase(groupID, artifactID, MavenRepositoryURL)
+}
+
+// GetMavenLatestVersionWithBase reads maven-metadata.xml of an artifact from the repository
+// at base and returns its highest version, preferring releases over pre-releases. The
+// <release> element is not trusted since it names the most recently deployed version,
+// which may be a backport or a release candidate.
+func GetMavenLatestVersionWithBase(groupID, artifactID, base string) (string, error) {
+	meta, err := GetMavenMetadataWithBase(groupID, artifactID, base)
+	if err != nil {
+		return "", err
+	}
+	versions := meta.Versioning.Versions
+	if len(versions) == 0 && meta.Versioning.Release != "" {
+		versions = []string{meta.Versioning.Release}
+	}
+	var release, prerelease string
+	for _, v := range versions {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasSuffix(v, "-SNAPSHOT") {
+			continue
+		}
+		best := &release
+		if version.MavenPrerelease(v) {
+			best = &prerelease
+		}
+		if c, ok := version.Maven.Compare(v, *best); *best == "" || (ok && c > 0) {
+			*best = v
+		}
+	}
+	if v := firstNonEmpty(release, prerelease); v != "" {
+		return v, nil
+	}
+	return "", fmt.Errorf("no release version in maven-metadata.xml for %s:%s", groupID, artifactID)
+}
+
+// GetMavenMetadataWithBase fetches maven-metadata.xml of an artifact from the repository at base.
+func GetMavenMetadataWithBase(groupID, artifactID, base string) (*MavenMetadata, error) {
+	url := mavenArtifactURL(base, groupID, artifactID) + "/maven-metadata.xml"
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch maven metadata for %s:%s: %w", groupID, artifactID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("maven repository returned status %d for %s:%s", resp.StatusCode, groupID, artifactID)
+	}
+
+	var meta MavenMetadata
+	if err := xml.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, fmt.Errorf("failed to decode maven metadata for %s:%s: %w", groupID, artifactID, err)
+	}
+	return &meta, nil
+}
+
+// FetchMavenChangelogInfo reads the SCM URL from the POM of latestVersion and
+// summarizes the changelog of that repository.
+func FetchMavenChangelogInfo(groupID, artifactID, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	name := groupID + ":" + artifactID
+	url := fmt.Sprintf("%s/%s/%s-%s.pom", mavenArtifactURL(MavenRepositoryURL, groupID, artifactID), latestVersion, artifactID, latestVersion)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch POM for %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("maven repository returned status %d for %s", resp.StatusCode, name)
+	}
+	var pom struct {
+		URL string `xml:"url"`
+		SCM struct {
+			URL string `xml:"url"`
+		} `xml:"scm"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&pom); err != nil {
+		return nil, fmt.Errorf("failed to decode POM for %s: %w", name, err)
+	}
+	repoURL := pom.SCM.URL
+	if !strings.Contains(repoURL, "github.com/") && strings.Contains(pom.URL, "github.com/") {
+		repoURL = pom.URL
+	}
+	repoURL = strings.TrimPrefix(repoURL, "scm:git:")
+	return ChangelogInfoFromRepo(name, strings.TrimSuffix(repoURL, "/"), currentVersion, latestVersion), nil
+}
+
+func mavenArtifactURL(base, groupID, artifactID string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(base, "/"), strings.ReplaceAll(groupID, ".", "/"), artifactID)
+}
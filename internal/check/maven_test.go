@@ -0,0 +1,84 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetMavenLatestVersion_Success(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/com/fasterxml/jackson/core/jackson-databind/maven-metadata.xml" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`<metadata>
+  <groupId>com.fasterxml.jackson.core</groupId>
+  <artifactId>jackson-databind</artifactId>
+  <versioning>
+    <latest>2.17.0-SNAPSHOT</latest>
+    <release>2.16.1</release>
+    <versions><version>2.16.0</version><version>2.16.1</version></versions>
+  </versioning>
+</metadata>`))
+	}))
+	defer ts.Close()
+
+	latest, err := GetMavenLatestVersionWithBase("com.fasterxml.jackson.core", "jackson-databind", ts.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "2.16.1" {
+		t.Errorf("expected 2.16.1, got %s", latest)
+	}
+}
+
+func TestGetMavenLatestVersion_NoRelease(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<metadata><versioning><versions><version>1.0</version><version>1.1-SNAPSHOT</version></versions></versioning></metadata>`))
+	}))
+	defer ts.Close()
+
+	latest, err := GetMavenLatestVersionWithBase("g", "a", ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "1.0" {
+		t.Errorf("expected 1.0, got %s", latest)
+	}
+}
+
+func TestGetMavenLatestVersion_HighestVersion(t *testing.T) {
+	cases := map[string]string{
+		// A 5.3.x backport deployed after 6.1.2 is still older.
+		`<release>5.3.31</release><versions><version>5.3.30</version><version>6.1.2</version><version>5.3.31</version></versions>`: "6.1.2",
+		// A release candidate deployed last is not preferred over a release.
+		`<release>7.0.0-RC1</release><versions><version>6.1.2</version><version>7.0.0-M3</version><version>7.0.0-RC1</version></versions>`: "6.1.2",
+		// Without releases the highest pre-release is used.
+		`<versions><version>1.0.0-beta1</version><version>1.0.0-rc2</version><version>1.0.0-SNAPSHOT</version></versions>`: "1.0.0-rc2",
+		// Unknown qualifiers such as -jre are releases.
+		`<versions><version>32.1.3-jre</version><version>33.0.0-android</version><version>33.0.0-jre</version></versions>`: "33.0.0-jre",
+	}
+	for versioning, want := range cases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<metadata><versioning>` + versioning + `</versioning></metadata>`))
+		}))
+		latest, err := GetMavenLatestVersionWithBase("g", "a", ts.URL)
+		ts.Close()
+		if err != nil || latest != want {
+			t.Errorf("%s: expected %s, got %q (%v)", versioning, want, latest, err)
+		}
+	}
+}
+
+func TestGetMavenLatestVersion_404(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	_, err := GetMavenLatestVersionWithBase("g", "missing", ts.URL)
+	if err == nil {
+		t.Error("expected error for 404, got nil")
+	}
+}
@@ -4,29 +4,95 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 )
 
-// GetNpmLatestVersion queries the npm registry for the latest version of a package.
+// NpmRegistryURL is the base URL of the npm registry.
+var NpmRegistryURL = "https://registry.npmjs.org/"
+
+// NpmDistTag is the dist-tag (release channel) packages are compared against
+// unless NpmPackageDistTags selects another one.
+var NpmDistTag = "latest"
+
+// NpmPackageDistTags maps package names to the dist-tag they track, e.g.
+// "typescript" to "next".
+var NpmPackageDistTags = map[string]string{}
+
+// NpmDistTagFor returns the dist-tag pkg is compared against.
+func NpmDistTagFor(pkg string) string {
+	if tag := NpmPackageDistTags[pkg]; tag != "" {
+		return tag
+	}
+	if NpmDistTag != "" {
+		return NpmDistTag
+	}
+	return "latest"
+}
+
+// NpmPackument is the registry document of a package, reduced to what depflow uses.
+type NpmPackument struct {
+	DistTags map[string]string          `json:"dist-tags"`
+	Versions map[string]json.RawMessage `json:"versions"`
+}
+
+// VersionList returns the published versions sorted as strings.
+func (p *NpmPackument) VersionList() []string {
+	versions := make([]string, 0, len(p.Versions))
+	for v := range p.Versions {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// GetNpmLatestVersion queries the npm registry for the latest version of a
+// package on the dist-tag it tracks, see NpmDistTagFor.
 func GetNpmLatestVersion(pkg string) (string, error) {
-	url := fmt.Sprintf("https://registry.npmjs.org/%s", pkg)
-	resp, err := http.Get(url)
+	return GetNpmLatestVersionWithBase(pkg, NpmRegistryURL)
+}
+
+// GetNpmLatestVersionWithBase is GetNpmLatestVersion against the registry at base.
+func GetNpmLatestVersionWithBase(pkg, base string) (string, error) {
+	doc, err := GetNpmPackageWithBase(pkg, base)
+	if err != nil {
+		return "", err
+	}
+	tag := NpmDistTagFor(pkg)
+	v, ok := doc.DistTags[tag]
+	if !ok {
+		return "", fmt.Errorf("npm package %s has no dist-tag %q", pkg, tag)
+	}
+	return v, nil
+}
+
+// GetNpmPackage fetches the registry document of a package.
+func GetNpmPackage(pkg string) (*NpmPackument, error) {
+	return GetNpmPackageWithBase(pkg, NpmRegistryURL)
+}
+
+// GetNpmPackageWithBase fetches the registry document of a package from the
+// registry at base. The abbreviated install format is requested since it
+// still lists every version and dist-tag.
+func GetNpmPackageWithBase(pkg, base string) (*NpmPackument, error) {
+	url := fmt.Sprintf("%s%s", base, pkg)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch npm info for %s: %w", pkg, err)
+	}
+	req.Header.Set("Accept", "application/vnd.npm.install-v1+json; q=1.0, application/json; q=0.8")
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch npm info for %s: %w", pkg, err)
+		return nil, fmt.Errorf("failed to fetch npm info for %s: %w", pkg, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("npm registry returned status %d for %s", resp.StatusCode, pkg)
+		return nil, fmt.Errorf("npm registry returned status %d for %s", resp.StatusCode, pkg)
 	}
 
-	var data struct {
-		DistTags struct {
-			Latest string `json:"latest"`
-		} `json:"dist-tags"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", fmt.Errorf("failed to decode npm registry response for %s: %w", pkg, err)
+	var doc NpmPackument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode npm registry response for %s: %w", pkg, err)
 	}
-
-	return data.DistTags.Latest, nil
+	return &doc, nil
 }
@@ -2,7 +2,6 @@ package check
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,9 +15,9 @@ func TestGetNpmLatestVersion_Success(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	oldURL := npmRegistryURL
-	npmRegistryURL = ts.URL + "/"
-	defer func() { npmRegistryURL = oldURL }()
+	oldURL := NpmRegistryURL
+	NpmRegistryURL = ts.URL + "/"
+	defer func() { NpmRegistryURL = oldURL }()
 
 	latest, err := GetNpmLatestVersionWithBase("testpkg", ts.URL+"/")
 	if err != nil {
@@ -48,29 +47,47 @@ func TestGetNpmLatestVersion_NetworkError(t *testing.T) {
 	}
 }
 
-// Helper for testing: allows base URL override
-var npmRegistryURL = "https://registry.npmjs.org/"
+func TestGetNpmPackage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/@types/node" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"name":"@types/node","dist-tags":{"latest":"20.1.0","next":"21.0.0-beta.1"},
+			"versions":{"20.0.0":{},"20.1.0":{},"21.0.0-beta.1":{}}}`))
+	}))
+	defer ts.Close()
 
-func GetNpmLatestVersionWithBase(pkg, base string) (string, error) {
-	url := fmt.Sprintf("%s%s", base, pkg)
-	resp, err := http.Get(url)
+	doc, err := GetNpmPackageWithBase("@types/node", ts.URL+"/")
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch npm info for %s: %w", pkg, err)
+		t.Fatalf("unexpected error: %v", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("npm registry returned status %d for %s", resp.StatusCode, pkg)
+	if doc.DistTags["next"] != "21.0.0-beta.1" {
+		t.Errorf("unexpected dist-tags: %v", doc.DistTags)
+	}
+	if got := doc.VersionList(); len(got) != 3 || got[0] != "20.0.0" {
+		t.Errorf("unexpected versions: %v", got)
 	}
+}
+
+func TestGetNpmLatestVersion_DistTag(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"dist-tags":{"latest":"5.3.3","next":"5.4.0-dev.20240101","beta":"5.4.0-beta"}}`))
+	}))
+	defer ts.Close()
+	oldTag, oldTags := NpmDistTag, NpmPackageDistTags
+	defer func() { NpmDistTag, NpmPackageDistTags = oldTag, oldTags }()
 
-	var data struct {
-		DistTags struct {
-			Latest string `json:"latest"`
-		} `json:"dist-tags"`
+	NpmDistTag = "beta"
+	NpmPackageDistTags = map[string]string{"typescript": "next", "react": "canary"}
+	cases := map[string]string{"typescript": "5.4.0-dev.20240101", "lodash": "5.4.0-beta"}
+	for pkg, want := range cases {
+		got, err := GetNpmLatestVersionWithBase(pkg, ts.URL+"/")
+		if err != nil || got != want {
+			t.Errorf("GetNpmLatestVersionWithBase(%s) = %q, %v; want %q", pkg, got, err, want)
+		}
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", fmt.Errorf("failed to decode npm registry response for %s: %w", pkg, err)
+	if _, err := GetNpmLatestVersionWithBase("react", ts.URL+"/"); err == nil {
+		t.Error("expected error for a missing dist-tag")
 	}
-
-	return data.DistTags.Latest, nil
 }
@@ -0,0 +1,148 @@
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/version"
+)
+
+// NuGetServiceIndexURL is the NuGet v3 service index. Its PackageBaseAddress
+// resource is the flat container that lists package versions.
+var NuGetServiceIndexURL = "https://api.nuget.org/v3/index.json"
+
+// nugetBaseAddresses caches the flat container address per service index.
+var nugetBaseAddresses = map[string]string{}
+
+// GetNuGetLatestVersion returns the newest stable version of a package from the
+// feed at NuGetServiceIndexURL, or the newest prerelease if it has no stable one.
+func GetNuGetLatestVersion(id string) (string, error) {
+	return GetNuGetLatestVersionWithBase(id, NuGetServiceIndexURL)
+}
+
+// GetNuGetLatestVersionWithBase is GetNuGetLatestVersion against the feed whose
+// service index is serviceIndex.
+func GetNuGetLatestVersionWithBase(id, serviceIndex string) (string, error) {
+	versions, err := GetNuGetVersionsWithBase(id, serviceIndex)
+	if err != nil {
+		return "", err
+	}
+	latest, latestPre := "", ""
+	for _, v := range versions {
+		if strings.Contains(strings.SplitN(v, "+", 2)[0], "-") {
+			if latestPre == "" || nugetNewer(v, latestPre) {
+				latestPre = v
+			}
+		} else if latest == "" || nugetNewer(v, latest) {
+			latest = v
+		}
+	}
+	if latest == "" {
+		latest = latestPre
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no versions found for %s", id)
+	}
+	return latest, nil
+}
+
+func nugetNewer(a, b string) bool {
+	c, _ := version.NuGet.Compare(a, b)
+	return c > 0
+}
+
+// GetNuGetVersionsWithBase lists all versions of a package from the flat
+// container of the feed at serviceIndex.
+func GetNuGetVersionsWithBase(id, serviceIndex string) ([]string, error) {
+	base, err := GetNuGetPackageBaseAddress(serviceIndex)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Versions []string `json:"versions"`
+	}
+	if err := getNuGetJSON(base+strings.ToLower(id)+"/index.json", id, &data); err != nil {
+		return nil, err
+	}
+	return data.Versions, nil
+}
+
+// GetNuGetPackageBaseAddress reads the PackageBaseAddress/3.0.0 resource from a
+// service index.
+func GetNuGetPackageBaseAddress(serviceIndex string) (string, error) {
+	if base, ok := nugetBaseAddresses[serviceIndex]; ok {
+		return base, nil
+	}
+	var index struct {
+		Resources []struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		} `json:"resources"`
+	}
+	if err := getNuGetJSON(serviceIndex, "service index", &index); err != nil {
+		return "", err
+	}
+	for _, r := range index.Resources {
+		if r.Type == "PackageBaseAddress/3.0.0" {
+			base := strings.TrimSuffix(r.ID, "/") + "/"
+			nugetBaseAddresses[serviceIndex] = base
+			return base, nil
+		}
+	}
+	return "", fmt.Errorf("service index %s has no PackageBaseAddress resource", serviceIndex)
+}
+
+func getNuGetJSON(url, what string, v interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch NuGet %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("NuGet feed returned status %d for %s", resp.StatusCode, what)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode NuGet response for %s: %w", what, err)
+	}
+	return nil
+}
+
+// FetchNuGetChangelogInfo reads the repository of a package from the .nuspec
+// of its latest version and summarizes its changelog.
+func FetchNuGetChangelogInfo(id, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	base, err := GetNuGetPackageBaseAddress(NuGetServiceIndexURL)
+	if err != nil {
+		return nil, err
+	}
+	lower := strings.ToLower(id)
+	url := fmt.Sprintf("%s%s/%s/%s.nuspec", base, lower, strings.ToLower(latestVersion), lower)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nuspec for %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("NuGet feed returned status %d for %s nuspec", resp.StatusCode, id)
+	}
+	var nuspec struct {
+		Metadata struct {
+			ProjectURL string `xml:"projectUrl"`
+			Repository struct {
+				URL string `xml:"url,attr"`
+			} `xml:"repository"`
+		} `xml:"metadata"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&nuspec); err != nil {
+		return nil, fmt.Errorf("failed to decode nuspec for %s: %w", id, err)
+	}
+	repoURL := nuspec.Metadata.Repository.URL
+	if !strings.Contains(repoURL, "github.com/") && strings.Contains(nuspec.Metadata.ProjectURL, "github.com/") {
+		repoURL = nuspec.Metadata.ProjectURL
+	}
+	return ChangelogInfoFromRepo(id, strings.TrimSuffix(repoURL, "/"), currentVersion, latestVersion), nil
+}
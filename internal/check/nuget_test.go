@@ -0,0 +1,68 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newNuGetServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/index.json":
+			w.Write([]byte(`{"version": "3.0.0", "resources": [
+				{"@id": "` + ts.URL + `/query", "@type": "SearchQueryService"},
+				{"@id": "` + ts.URL + `/flat", "@type": "PackageBaseAddress/3.0.0"}
+			]}`))
+		case "/flat/newtonsoft.json/index.json":
+			w.Write([]byte(`{"versions": ["12.0.3", "13.0.1", "13.0.3", "13.0.4-beta1"]}`))
+		case "/flat/preview.only/index.json":
+			w.Write([]byte(`{"versions": ["1.0.0-alpha.2", "1.0.0-alpha.10"]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestGetNuGetLatestVersion_Success(t *testing.T) {
+	ts := newNuGetServer(t)
+	index := ts.URL + "/v3/index.json"
+
+	latest, err := GetNuGetLatestVersionWithBase("Newtonsoft.Json", index)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "13.0.3" {
+		t.Errorf("expected 13.0.3, got %s", latest)
+	}
+	latest, err = GetNuGetLatestVersionWithBase("Preview.Only", index)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "1.0.0-alpha.10" {
+		t.Errorf("expected 1.0.0-alpha.10, got %s", latest)
+	}
+}
+
+func TestGetNuGetLatestVersion_404(t *testing.T) {
+	ts := newNuGetServer(t)
+	_, err := GetNuGetLatestVersionWithBase("Missing.Package", ts.URL+"/v3/index.json")
+	if err == nil {
+		t.Error("expected error for 404, got nil")
+	}
+}
+
+func TestGetNuGetPackageBaseAddress_Missing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"resources": []}`))
+	}))
+	defer ts.Close()
+
+	if _, err := GetNuGetPackageBaseAddress(ts.URL); err == nil {
+		t.Error("expected error for service index without PackageBaseAddress, got nil")
+	}
+}
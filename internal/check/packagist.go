@@ -0,0 +1,149 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/version"
+)
+
+// PackagistURL is the base URL of a Composer repository serving the
+// p2/<vendor>/<package>.json metadata format.
+var PackagistURL = "https://repo.packagist.org/"
+
+// PackagistVersion is one release of a package in the p2 metadata.
+type PackagistVersion struct {
+	Version           string `json:"version"`
+	VersionNormalized string `json:"version_normalized"`
+	Source            struct {
+		URL string `json:"url"`
+	} `json:"source"`
+}
+
+// Composer stabilities from least to most stable, keyed in lower case since
+// Composer accepts them in any case.
+var composerStabilityRank = map[string]int{"dev": 0, "alpha": 1, "beta": 2, "rc": 3, "stable": 4}
+
+// GetPackagistLatestVersion returns the newest release of a package on
+// PackagistURL that is at least as stable as minStability (stable, RC, beta,
+// alpha or dev).
+func GetPackagistLatestVersion(pkg, minStability string) (string, error) {
+	return GetPackagistLatestVersionWithBase(pkg, minStability, PackagistURL)
+}
+
+// GetPackagistLatestVersionWithBase is GetPackagistLatestVersion against the repository at base.
+func GetPackagistLatestVersionWithBase(pkg, minStability, base string) (string, error) {
+	minRank := composerStabilityRank["stable"]
+	if minStability != "" {
+		rank, ok := composerStabilityRank[strings.ToLower(minStability)]
+		if !ok {
+			return "", fmt.Errorf("unknown minimum-stability %q for %s", minStability, pkg)
+		}
+		minRank = rank
+	}
+	versions, err := GetPackagistVersionsWithBase(pkg, base)
+	if err != nil {
+		return "", err
+	}
+	var latest *PackagistVersion
+	for i := range versions {
+		v := &versions[i]
+		// Branches such as dev-main have no comparable version.
+		if strings.HasPrefix(v.VersionNormalized, "dev-") || composerStabilityRank[strings.ToLower(ComposerStability(v.VersionNormalized))] < minRank {
+			continue
+		}
+		if latest == nil || composerNewer(v.VersionNormalized, latest.VersionNormalized) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no %s release found for %s", minStability, pkg)
+	}
+	return latest.Version, nil
+}
+
+// GetPackagistVersionsWithBase fetches p2/<pkg>.json from base and expands
+// the minified version list.
+func GetPackagistVersionsWithBase(pkg, base string) ([]PackagistVersion, error) {
+	url := fmt.Sprintf("%s/p2/%s.json", strings.TrimSuffix(base, "/"), strings.ToLower(pkg))
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Packagist metadata for %s: %w", pkg, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Packagist returned status %d for %s", resp.StatusCode, pkg)
+	}
+
+	var data struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+		Minified string                                  `json:"minified"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode Packagist response for %s: %w", pkg, err)
+	}
+
+	versions := []PackagistVersion{}
+	// In the minified format each entry only lists the fields that differ from
+	// the previous one; "__unset" removes a field.
+	expanded := make(map[string]json.RawMessage)
+	for _, entry := range data.Packages[strings.ToLower(pkg)] {
+		if data.Minified == "" {
+			expanded = make(map[string]json.RawMessage)
+		}
+		for key, value := range entry {
+			if string(value) == `"__unset"` {
+				delete(expanded, key)
+			} else {
+				expanded[key] = value
+			}
+		}
+		raw, _ := json.Marshal(expanded)
+		var v PackagistVersion
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode Packagist version of %s: %w", pkg, err)
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func composerNewer(a, b string) bool {
+	c, _ := version.Composer.Compare(a, b)
+	return c > 0
+}
+
+// ComposerStability returns the stability of a normalized Composer version
+// such as "1.2.0.0-beta2". Patch releases count as stable.
+func ComposerStability(normalized string) string {
+	if strings.HasPrefix(normalized, "dev-") || strings.HasSuffix(normalized, "-dev") {
+		return "dev"
+	}
+	_, suffix, _ := strings.Cut(normalized, "-")
+	for _, s := range []string{"alpha", "beta", "RC"} {
+		if strings.HasPrefix(suffix, s) {
+			return s
+		}
+	}
+	return "stable"
+}
+
+// FetchPackagistChangelogInfo finds the source repository of a Composer package
+// and summarizes its changelog between currentVersion and latestVersion.
+func FetchPackagistChangelogInfo(pkg, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	versions, err := GetPackagistVersionsWithBase(pkg, PackagistURL)
+	if err != nil {
+		return nil, err
+	}
+	repoURL := ""
+	for _, v := range versions {
+		if v.Version == latestVersion || (repoURL == "" && v.Source.URL != "") {
+			repoURL = v.Source.URL
+		}
+	}
+	return ChangelogInfoFromRepo(pkg, strings.TrimSuffix(repoURL, "/"), currentVersion, latestVersion), nil
+}
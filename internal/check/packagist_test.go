@@ -0,0 +1,80 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// A minified p2 document: later entries only carry fields that changed.
+const monologMetadata = `{
+  "minified": "composer/2.0",
+  "packages": {
+    "monolog/monolog": [
+      {"version": "4.0.0-RC1", "version_normalized": "4.0.0.0-RC1", "source": {"type": "git", "url": "https://github.com/Seldaek/monolog.git"}},
+      {"version": "3.5.0", "version_normalized": "3.5.0.0"},
+      {"version": "3.4.0", "version_normalized": "3.4.0.0"},
+      {"version": "3.0.0-beta1", "version_normalized": "3.0.0.0-beta1", "source": "__unset"},
+      {"version": "dev-main", "version_normalized": "dev-main"}
+    ]
+  }
+}`
+
+func newPackagistServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/p2/monolog/monolog.json" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(monologMetadata))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestGetPackagistLatestVersion_Stability(t *testing.T) {
+	ts := newPackagistServer(t)
+	cases := map[string]string{"stable": "3.5.0", "RC": "4.0.0-RC1", "rc": "4.0.0-RC1", "Beta": "4.0.0-RC1", "dev": "4.0.0-RC1", "": "3.5.0"}
+	for stability, want := range cases {
+		got, err := GetPackagistLatestVersionWithBase("monolog/monolog", stability, ts.URL)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", stability, err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %s, got %s", stability, want, got)
+		}
+	}
+}
+
+func TestGetPackagistLatestVersion_UnknownStability(t *testing.T) {
+	ts := newPackagistServer(t)
+	if _, err := GetPackagistLatestVersionWithBase("monolog/monolog", "experimental", ts.URL); err == nil {
+		t.Error("expected error for an unknown minimum-stability, got nil")
+	}
+}
+
+func TestGetPackagistVersions_Minified(t *testing.T) {
+	ts := newPackagistServer(t)
+	versions, err := GetPackagistVersionsWithBase("Monolog/Monolog", ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 5 {
+		t.Fatalf("expected 5 versions, got %d", len(versions))
+	}
+	if versions[2].Source.URL != "https://github.com/Seldaek/monolog.git" {
+		t.Errorf("expected inherited source URL, got %q", versions[2].Source.URL)
+	}
+	if versions[3].Source.URL != "" {
+		t.Errorf("expected unset source URL, got %q", versions[3].Source.URL)
+	}
+}
+
+func TestGetPackagistLatestVersion_404(t *testing.T) {
+	ts := newPackagistServer(t)
+	_, err := GetPackagistLatestVersionWithBase("acme/missing", "stable", ts.URL)
+	if err == nil {
+		t.Error("expected error for 404, got nil")
+	}
+}
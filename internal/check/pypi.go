@@ -0,0 +1,91 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// PyPIIndexURL is the base URL of the PyPI JSON API. It can point at a mirror
+// serving the same /pypi/<name>/json documents.
+var PyPIIndexURL = "https://pypi.org/"
+
+// PyPIProject is the part of the PyPI JSON API response depflow uses.
+type PyPIProject struct {
+	Info struct {
+		Name        string            `json:"name"`
+		Version     string            `json:"version"`
+		License     string            `json:"license"`
+		HomePage    string            `json:"home_page"`
+		ProjectURLs map[string]string `json:"project_urls"`
+	} `json:"info"`
+}
+
+// GetPyPILatestVersion queries PyPIIndexURL for the latest version of a package.
+func GetPyPILatestVersion(pkg string) (string, error) {
+	return GetPyPILatestVersionWithBase(pkg, PyPIIndexURL)
+}
+
+// GetPyPILatestVersionWithBase queries the PyPI JSON API at base for the latest version of a package.
+func GetPyPILatestVersionWithBase(pkg, base string) (string, error) {
+	project, err := GetPyPIProjectWithBase(pkg, base)
+	if err != nil {
+		return "", err
+	}
+	return project.Info.Version, nil
+}
+
+// GetPyPIProjectWithBase fetches /pypi/<pkg>/json from the index at base.
+func GetPyPIProjectWithBase(pkg, base string) (*PyPIProject, error) {
+	url := fmt.Sprintf("%s/pypi/%s/json", strings.TrimSuffix(base, "/"), pkg)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PyPI info for %s: %w", pkg, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("PyPI returned status %d for %s", resp.StatusCode, pkg)
+	}
+
+	var project PyPIProject
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("failed to decode PyPI response for %s: %w", pkg, err)
+	}
+	return &project, nil
+}
+
+var (
+	pypiRepoKeyRe      = regexp.MustCompile(`(?i)^(source|source code|repository|code|github|homepage)$`)
+	pypiChangelogKeyRe = regexp.MustCompile(`(?i)change|release`)
+)
+
+// FetchPyPIChangelogInfo finds the source repository of a PyPI project and
+// summarizes its changelog between currentVersion and latestVersion.
+func FetchPyPIChangelogInfo(pkg, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	project, err := GetPyPIProjectWithBase(pkg, PyPIIndexURL)
+	if err != nil {
+		return nil, err
+	}
+	repoURL := ""
+	for key, u := range project.Info.ProjectURLs {
+		if pypiRepoKeyRe.MatchString(key) && strings.Contains(u, "github.com/") {
+			repoURL = u
+			break
+		}
+	}
+	if repoURL == "" && strings.Contains(project.Info.HomePage, "github.com/") {
+		repoURL = project.Info.HomePage
+	}
+	info := ChangelogInfoFromRepo(pkg, strings.TrimSuffix(repoURL, "/"), currentVersion, latestVersion)
+	for key, u := range project.Info.ProjectURLs {
+		if info.ChangelogURL == "" && pypiChangelogKeyRe.MatchString(key) {
+			info.ChangelogURL = u
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,52 @@
+package check
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPyPILatestVersion_Success(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pypi/requests/json" {
+			w.WriteHeader(404)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"info": map[string]string{"name": "requests", "version": "2.31.0"},
+		})
+	}))
+	defer ts.Close()
+
+	oldURL := PyPIIndexURL
+	PyPIIndexURL = ts.URL + "/"
+	defer func() { PyPIIndexURL = oldURL }()
+
+	latest, err := GetPyPILatestVersion("requests")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "2.31.0" {
+		t.Errorf("expected 2.31.0, got %s", latest)
+	}
+}
+
+func TestGetPyPILatestVersion_404(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	_, err := GetPyPILatestVersionWithBase("notfound", ts.URL)
+	if err == nil {
+		t.Error("expected error for 404, got nil")
+	}
+}
+
+func TestGetPyPILatestVersion_NetworkError(t *testing.T) {
+	_, err := GetPyPILatestVersionWithBase("pkg", "http://localhost:0/")
+	if err == nil {
+		t.Error("expected network error, got nil")
+	}
+}
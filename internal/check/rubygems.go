@@ -0,0 +1,88 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// RubyGemsURL is the base URL of the RubyGems API. It can point at any server
+// implementing the /api/v1 endpoints.
+var RubyGemsURL = "https://rubygems.org/"
+
+// RubyGem is the part of the /api/v1/gems/<gem>.json response depflow uses.
+type RubyGem struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	SourceCodeURI string `json:"source_code_uri"`
+	ChangelogURI  string `json:"changelog_uri"`
+	HomepageURI   string `json:"homepage_uri"`
+}
+
+// GetRubyGemLatestVersion queries RubyGemsURL for the latest version of a gem.
+func GetRubyGemLatestVersion(gem string) (string, error) {
+	return GetRubyGemLatestVersionWithBase(gem, RubyGemsURL)
+}
+
+// GetRubyGemLatestVersionWithBase queries /api/v1/versions/<gem>/latest.json at base.
+func GetRubyGemLatestVersionWithBase(gem, base string) (string, error) {
+	url := fmt.Sprintf("%s/api/v1/versions/%s/latest.json", strings.TrimSuffix(base, "/"), gem)
+	var data struct {
+		Version string `json:"version"`
+	}
+	if err := getRubyGemsJSON(url, gem, &data); err != nil {
+		return "", err
+	}
+	// RubyGems answers unknown gems with "unknown" instead of a 404.
+	if data.Version == "" || data.Version == "unknown" {
+		return "", fmt.Errorf("no version found for %s", gem)
+	}
+	return data.Version, nil
+}
+
+// GetRubyGemWithBase fetches /api/v1/gems/<gem>.json from base.
+func GetRubyGemWithBase(gem, base string) (*RubyGem, error) {
+	url := fmt.Sprintf("%s/api/v1/gems/%s.json", strings.TrimSuffix(base, "/"), gem)
+	var info RubyGem
+	if err := getRubyGemsJSON(url, gem, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+func getRubyGemsJSON(url, gem string, v interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch RubyGems info for %s: %w", gem, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("RubyGems returned status %d for %s", resp.StatusCode, gem)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode RubyGems response for %s: %w", gem, err)
+	}
+	return nil
+}
+
+// FetchRubyGemChangelogInfo finds the source repository of a gem and summarizes
+// its changelog between currentVersion and latestVersion.
+func FetchRubyGemChangelogInfo(gem, currentVersion, latestVersion string) (*model.ChangelogInfo, error) {
+	info, err := GetRubyGemWithBase(gem, RubyGemsURL)
+	if err != nil {
+		return nil, err
+	}
+	repoURL := info.SourceCodeURI
+	if !strings.Contains(repoURL, "github.com/") && strings.Contains(info.HomepageURI, "github.com/") {
+		repoURL = info.HomepageURI
+	}
+	changelog := ChangelogInfoFromRepo(gem, strings.TrimSuffix(repoURL, "/"), currentVersion, latestVersion)
+	if info.ChangelogURI != "" && len(changelog.Highlights) == 0 {
+		changelog.ChangelogURL = info.ChangelogURI
+	}
+	return changelog, nil
+}
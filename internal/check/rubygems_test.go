@@ -0,0 +1,60 @@
+package check
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRubyGemLatestVersion_Success(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/versions/rake/latest.json":
+			w.Write([]byte(`{"version":"13.1.0"}`))
+		case "/api/v1/versions/nosuchgem/latest.json":
+			w.Write([]byte(`{"version":"unknown"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	oldURL := RubyGemsURL
+	RubyGemsURL = ts.URL + "/"
+	defer func() { RubyGemsURL = oldURL }()
+
+	latest, err := GetRubyGemLatestVersion("rake")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != "13.1.0" {
+		t.Errorf("expected 13.1.0, got %s", latest)
+	}
+	if _, err := GetRubyGemLatestVersion("nosuchgem"); err == nil {
+		t.Error("expected error for unknown gem, got nil")
+	}
+}
+
+func TestGetRubyGemLatestVersion_404(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	_, err := GetRubyGemLatestVersionWithBase("notfound", ts.URL)
+	if err == nil {
+		t.Error("expected error for 404, got nil")
+	}
+}
+
+func TestGetRubyGemLatestVersion_BadJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer ts.Close()
+
+	_, err := GetRubyGemLatestVersionWithBase("rake", ts.URL)
+	if err == nil {
+		t.Error("expected error for bad JSON, got nil")
+	}
+}
@@ -0,0 +1,34 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Bun handles the bun.lock text lock file. Packages are looked up in the npm registry.
+type Bun struct{}
+
+// NewBun returns the Bun ecosystem.
+func NewBun() *Bun { return &Bun{} }
+
+func (*Bun) Name() string        { return "bun" }
+func (*Bun) Title() string       { return "Bun (bun.lock)" }
+func (*Bun) LockFiles() []string { return []string{"bun.lock"} }
+
+func (b *Bun) Detect(dir string) (string, error) {
+	return detectLockFile(dir, b.LockFiles()...)
+}
+
+func (*Bun) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseBunLockFile(path)
+}
+
+func (*Bun) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveNpmLatest(deps)
+	return nil
+}
+
+func (*Bun) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchChangelogInfo(name, current, latest)
+}
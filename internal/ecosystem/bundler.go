@@ -0,0 +1,51 @@
+package ecosystem
+
+import (
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Bundler handles Gemfile.lock files. Gems from gem servers are looked up in
+// the RubyGems API; git gems are reported without a latest version.
+type Bundler struct{}
+
+// NewBundler returns the Bundler ecosystem.
+func NewBundler() *Bundler { return &Bundler{} }
+
+func (*Bundler) Name() string        { return "bundler" }
+func (*Bundler) Title() string       { return "Ruby (Gemfile.lock)" }
+func (*Bundler) LockFiles() []string { return []string{"Gemfile.lock"} }
+
+func (b *Bundler) Detect(dir string) (string, error) {
+	return detectLockFile(dir, b.LockFiles()...)
+}
+
+func (*Bundler) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseGemfileLock(path)
+}
+
+func (*Bundler) ResolveLatest(dir string, deps []model.Dependency) error {
+	latest := make(map[string]string)
+	for i := range deps {
+		if strings.HasPrefix(deps[i].Registry, "git+") {
+			continue
+		}
+		name := deps[i].Name
+		v, ok := latest[name]
+		if !ok {
+			v, _ = check.GetRubyGemLatestVersion(name)
+			latest[name] = v
+		}
+		if v != "" {
+			deps[i].Latest = v
+		}
+	}
+	return nil
+}
+
+func (*Bundler) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchRubyGemChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,58 @@
+package ecosystem
+
+import (
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Cargo handles Cargo.lock files. Registry crates are looked up in the crates.io
+// sparse index; git crates are reported without a latest version.
+type Cargo struct{}
+
+// NewCargo returns the Cargo ecosystem.
+func NewCargo() *Cargo { return &Cargo{} }
+
+func (*Cargo) Name() string        { return "cargo" }
+func (*Cargo) Title() string       { return "Rust (Cargo.lock)" }
+func (*Cargo) LockFiles() []string { return []string{"Cargo.lock"} }
+
+func (c *Cargo) Detect(dir string) (string, error) {
+	return detectLockFile(dir, c.LockFiles()...)
+}
+
+func (*Cargo) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseCargoLockFile(path)
+}
+
+// ResolveLatest sets the newest non-yanked version and flags locked versions
+// that have been yanked from the index.
+func (*Cargo) ResolveLatest(dir string, deps []model.Dependency) error {
+	index := make(map[string][]check.CrateVersion)
+	for i := range deps {
+		dep := &deps[i]
+		if strings.HasPrefix(dep.Registry, "git+") {
+			continue
+		}
+		versions, ok := index[dep.Name]
+		if !ok {
+			versions, _ = check.GetCrateVersions(dep.Name)
+			index[dep.Name] = versions
+		}
+		if latest := check.LatestCrateVersion(versions); latest != "" {
+			dep.Latest = latest
+		}
+		for _, v := range versions {
+			if v.Version == dep.Version && v.Yanked {
+				dep.Notes = append(dep.Notes, "yanked from the registry")
+			}
+		}
+	}
+	return nil
+}
+
+func (*Cargo) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchCrateChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,52 @@
+package ecosystem
+
+import (
+	"path/filepath"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Composer handles composer.lock files. Packages are looked up in a Composer
+// repository, respecting minimum-stability and per-package stability flags.
+type Composer struct{}
+
+// NewComposer returns the Composer ecosystem.
+func NewComposer() *Composer { return &Composer{} }
+
+func (*Composer) Name() string        { return "composer" }
+func (*Composer) Title() string       { return "PHP (composer.lock)" }
+func (*Composer) LockFiles() []string { return []string{"composer.lock"} }
+
+func (c *Composer) Detect(dir string) (string, error) {
+	return detectLockFile(dir, c.LockFiles()...)
+}
+
+func (*Composer) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseComposerLockFile(path)
+}
+
+func (*Composer) ResolveLatest(dir string, deps []model.Dependency) error {
+	lock, err := parse.ReadComposerLock(filepath.Join(dir, "composer.lock"))
+	if err != nil {
+		return err
+	}
+	latest := make(map[string]string)
+	for i := range deps {
+		name := deps[i].Name
+		v, ok := latest[name]
+		if !ok {
+			v, _ = check.GetPackagistLatestVersion(name, lock.Stability(name))
+			latest[name] = v
+		}
+		if v != "" {
+			deps[i].Latest = v
+		}
+	}
+	return nil
+}
+
+func (*Composer) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchPackagistChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,130 @@
+// Package ecosystem ties lock file detection, parsing, version lookups and
+// changelog fetching together for every supported package ecosystem.
+package ecosystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// Ecosystem is implemented by every package ecosystem depflow can check.
+type Ecosystem interface {
+	// Name returns a short identifier such as "npm" or "go".
+	Name() string
+	// Title returns the report section header, e.g. "NPM (package-lock.json)".
+	Title() string
+	// LockFiles returns the file names this ecosystem knows how to read.
+	LockFiles() []string
+	// Detect returns the path of this ecosystem's lock file in dir, or "" if there is none.
+	Detect(dir string) (string, error)
+	// Parse reads the lock file at path and returns the dependencies it records.
+	Parse(path string) ([]model.Dependency, error)
+	// ResolveLatest sets Latest on the given dependencies. Dependencies whose
+	// latest version could not be determined are left untouched.
+	ResolveLatest(dir string, deps []model.Dependency) error
+	// FetchChangelog returns changelog information for an update of a dependency.
+	FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error)
+}
+
+// Section is one part of the report for a detected lock file.
+type Section struct {
+	Title        string
+	Dependencies []model.Dependency
+	Summary      string // optional line printed below the table
+}
+
+// Sectioner is implemented by ecosystems whose lock file can cover several
+// projects, such as a Go workspace. Sections splits checked dependencies into
+// report sections, or returns nil to report them under Title as usual.
+type Sectioner interface {
+	Sections(path string, deps []model.Dependency) []Section
+}
+
+var (
+	mu         sync.RWMutex
+	registry   []Ecosystem
+	registered = make(map[string]bool)
+)
+
+// Register makes an ecosystem available to the scanner and the root command.
+// It panics if an ecosystem with the same name is already registered.
+func Register(e Ecosystem) {
+	mu.Lock()
+	defer mu.Unlock()
+	if registered[e.Name()] {
+		panic(fmt.Sprintf("ecosystem: Register called twice for %s", e.Name()))
+	}
+	registered[e.Name()] = true
+	registry = append(registry, e)
+}
+
+// All returns the registered ecosystems in registration order.
+func All() []Ecosystem {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Ecosystem(nil), registry...)
+}
+
+// Lookup returns the registered ecosystem with the given name, or nil.
+func Lookup(name string) Ecosystem {
+	for _, e := range All() {
+		if e.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// LockFiles returns the lock file names of every registered ecosystem.
+// Names shared by several ecosystems are listed once.
+func LockFiles() []string {
+	files := []string{}
+	seen := make(map[string]bool)
+	for _, e := range All() {
+		for _, lf := range e.LockFiles() {
+			if !seen[lf] {
+				seen[lf] = true
+				files = append(files, lf)
+			}
+		}
+	}
+	return files
+}
+
+// detectLockFile returns the path of the first of names that exists in dir.
+func detectLockFile(dir string, names ...string) (string, error) {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+	}
+	return "", nil
+}
+
+func init() {
+	Register(NewNpm())
+	Register(NewYarn())
+	Register(NewYarnBerry())
+	Register(NewPnpm())
+	Register(NewBun())
+	Register(NewPip())
+	Register(NewPipenv())
+	Register(NewPoetry())
+	Register(NewUv())
+	Register(NewGo())
+	Register(NewMaven())
+	Register(NewGradle())
+	Register(NewCargo())
+	Register(NewBundler())
+	Register(NewComposer())
+	Register(NewNuGet())
+}
@@ -0,0 +1,92 @@
+package ecosystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"package-lock.json", "yarn.lock"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"npm", "yarn"} {
+		eco := Lookup(name)
+		if eco == nil {
+			t.Fatalf("ecosystem %s not registered", name)
+		}
+		path, err := eco.Detect(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if path != filepath.Join(dir, eco.LockFiles()[0]) {
+			t.Errorf("%s: unexpected path %q", name, path)
+		}
+	}
+	path, err := Lookup("go").Detect(dir)
+	if err != nil || path != "" {
+		t.Errorf("expected no go.mod, got %q (err %v)", path, err)
+	}
+}
+
+func TestDetectYarnFormat(t *testing.T) {
+	dir := t.TempDir()
+	berry := "__metadata:\n  version: 8\n"
+	if err := os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte(berry), 0644); err != nil {
+		t.Fatalf("failed to create yarn.lock: %v", err)
+	}
+	if path, err := Lookup("yarn").Detect(dir); err != nil || path != "" {
+		t.Errorf("classic yarn should not claim a berry lock file, got %q (err %v)", path, err)
+	}
+	if path, err := Lookup("yarn-berry").Detect(dir); err != nil || path == "" {
+		t.Errorf("yarn-berry should detect the lock file, got %q (err %v)", path, err)
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for duplicate registration")
+		}
+	}()
+	Register(NewNpm())
+}
+
+func TestLockFiles(t *testing.T) {
+	want := map[string]bool{"package-lock.json": true, "yarn.lock": true, "go.mod": true}
+	for _, lf := range LockFiles() {
+		delete(want, lf)
+	}
+	if len(want) != 0 {
+		t.Errorf("missing lock files: %v", want)
+	}
+	seen := make(map[string]bool)
+	for _, lf := range LockFiles() {
+		if seen[lf] {
+			t.Errorf("lock file %s listed twice", lf)
+		}
+		seen[lf] = true
+	}
+}
+
+func TestDetectNuGetProject(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"B.csproj", "A.csproj"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("<Project />"), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+	nuget := Lookup("nuget")
+	if path, err := nuget.Detect(dir); err != nil || path != filepath.Join(dir, "A.csproj") {
+		t.Errorf("expected A.csproj, got %q (err %v)", path, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "packages.lock.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to create packages.lock.json: %v", err)
+	}
+	if path, err := nuget.Detect(dir); err != nil || path != filepath.Join(dir, "packages.lock.json") {
+		t.Errorf("expected packages.lock.json to take precedence, got %q (err %v)", path, err)
+	}
+}
@@ -0,0 +1,370 @@
+package ecosystem
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// GoVersionChecker returns the latest available version of every module
+// required by the module in dir. It can be replaced with a mock in tests.
+type GoVersionChecker func(dir string) (map[string]string, error)
+
+// GoRetractionChecker returns the modules required by the module in dir whose
+// selected version has been retracted, with the retraction rationale.
+type GoRetractionChecker func(dir string) (map[string]string, error)
+
+// Go handles go.mod files and go.work workspaces. Modules are looked up
+// through Proxy when it is set, and with VersionChecker otherwise or when the
+// proxy cannot serve them.
+type Go struct {
+	Proxy          *check.GoProxy
+	VersionChecker GoVersionChecker
+	// RetractionChecker is optional; without it retractions are not reported.
+	RetractionChecker GoRetractionChecker
+	// IncludeIndirect lists // indirect requirements in the report. By default
+	// only direct requirements are listed and indirect ones are counted.
+	IncludeIndirect bool
+}
+
+// NewGo returns the Go ecosystem backed by the module proxies configured in
+// the environment. Modules the proxies cannot serve are checked with 'go list'.
+func NewGo() *Go {
+	return &Go{
+		Proxy:             check.NewGoProxyFromEnv(),
+		VersionChecker:    check.GetGoModuleLatestVersions,
+		RetractionChecker: check.GetGoRetractedVersions,
+	}
+}
+
+func (*Go) Name() string        { return "go" }
+func (*Go) Title() string       { return "Go (go.mod)" }
+func (*Go) LockFiles() []string { return []string{"go.work", "go.mod"} }
+
+func (g *Go) Detect(dir string) (string, error) {
+	return detectLockFile(dir, g.LockFiles()...)
+}
+
+// Parse reads a go.mod, or the go.mod of every module used by a go.work.
+func (*Go) Parse(path string) ([]model.Dependency, error) {
+	if filepath.Base(path) == "go.work" {
+		return parse.ParseGoWorkFile(path)
+	}
+	return parse.ParseGoModFile(path)
+}
+
+// ResolveLatest sets Latest from the module proxy or the version checker.
+// Replaced modules are checked against their replacement module, and
+// filesystem replacements are not checked. Retracted versions are flagged in
+// Notes, and pseudo-versions and +incompatible versions are described.
+func (g *Go) ResolveLatest(dir string, deps []model.Dependency) error {
+	if g.Proxy != nil {
+		g.resolveFromProxy(dir, deps)
+	} else if err := g.resolveFromChecker(dir, deps); err != nil {
+		return err
+	}
+	for i := range deps {
+		if _, current, ok := goCheckedModule(deps[i]); ok {
+			describePseudoVersion(&deps[i], current)
+		}
+	}
+	return nil
+}
+
+func (g *Go) resolveFromChecker(dir string, deps []model.Dependency) error {
+	if g.VersionChecker == nil {
+		return fmt.Errorf("no Go module proxy or version checker configured")
+	}
+	latest, err := g.VersionChecker(dir)
+	if err != nil {
+		return err
+	}
+	retracted := map[string]string{}
+	if g.RetractionChecker != nil {
+		// Retractions are advisory; a failed lookup must not fail the section.
+		if r, err := g.RetractionChecker(dir); err == nil {
+			retracted = r
+		}
+	}
+	for i := range deps {
+		applyGoListResult(&deps[i], latest, retracted)
+	}
+	return nil
+}
+
+// applyGoListResult sets Latest and retraction notes from the results of the
+// version and retraction checkers.
+func applyGoListResult(dep *model.Dependency, latest, retracted map[string]string) {
+	if dep.Replace != "" {
+		path, version := parse.SplitGoReplace(dep.Replace)
+		if v, ok := latest[path]; ok && version != "" {
+			dep.Latest = v
+		}
+		return
+	}
+	if v, ok := latest[dep.Name]; ok {
+		dep.Latest = v
+	}
+	if rationale, ok := retracted[dep.Name]; ok {
+		dep.Notes = append(dep.Notes, retractedNote(dep.Version, rationale))
+	}
+}
+
+// resolveUnproxied checks modules the proxy cannot serve with the version
+// checker, which runs the go command and so may reach version control. When
+// that is not possible the modules are flagged as not checked.
+func (g *Go) resolveUnproxied(dir string, deps []*model.Dependency, reasons []string) {
+	var latest, retracted map[string]string
+	err := fmt.Errorf("no version checker configured")
+	if g.VersionChecker != nil {
+		latest, err = g.VersionChecker(dir)
+	}
+	if err == nil && g.RetractionChecker != nil {
+		retracted, _ = g.RetractionChecker(dir)
+	}
+	for i, dep := range deps {
+		if err != nil {
+			dep.Notes = append(dep.Notes, "not checked: "+reasons[i])
+			continue
+		}
+		applyGoListResult(dep, latest, retracted)
+	}
+}
+
+// goCheckedModule returns the module path and version a dependency is checked
+// as: its replacement for module replacements. ok is false for filesystem
+// replacements, which have no version.
+func goCheckedModule(dep model.Dependency) (path, version string, ok bool) {
+	if dep.Replace == "" {
+		return dep.Name, dep.Version, true
+	}
+	path, version = parse.SplitGoReplace(dep.Replace)
+	return path, version, version != ""
+}
+
+// goMajorSuccessor is the highest major version path found for a module.
+type goMajorSuccessor struct {
+	path  string
+	query *check.GoModuleQuery
+}
+
+// resolveFromProxy lists each module once, even when several workspace
+// modules require it, and selects its latest version per requiring go.mod,
+// whose exclude directives rule out versions. Lookups that fail leave the
+// dependency untouched. Latest is only set when it is not older than the
+// current version, as with 'go list -u', so it stays within the module path's
+// major version; higher major version paths are reported in Notes. Modules the
+// proxy cannot serve, such as GONOPROXY ones, are left to resolveUnproxied.
+func (g *Go) resolveFromProxy(dir string, deps []model.Dependency) {
+	var unproxied []*model.Dependency
+	var reasons []string
+	manifests := make(map[string]*parse.GoModFile)
+	listings := make(map[string]*check.GoModuleListing)
+	unavailableReasons := make(map[string]string)
+	successors := make(map[string]*check.GoModuleQuery)
+	majorSuccessors := make(map[string]goMajorSuccessor)
+	for i := range deps {
+		dep := &deps[i]
+		path, current, ok := goCheckedModule(*dep)
+		if !ok {
+			continue
+		}
+		mf, ok := manifests[dep.ManifestPath]
+		if !ok {
+			mf, _ = parse.ReadGoModFile(dep.ManifestPath)
+			manifests[dep.ManifestPath] = mf
+		}
+		listing, ok := listings[path]
+		if !ok {
+			var err error
+			listing, err = g.Proxy.ListModule(path)
+			var unavailable *check.GoProxyUnavailableError
+			if errors.As(err, &unavailable) {
+				unavailableReasons[path] = unavailable.Reason
+			}
+			listings[path] = listing
+		}
+		if reason, ok := unavailableReasons[path]; ok {
+			unproxied = append(unproxied, dep)
+			reasons = append(reasons, reason)
+			continue
+		}
+		var q *check.GoModuleQuery
+		if listing != nil {
+			q, _ = listing.Select(current, func(v string) bool {
+				return mf != nil && mf.Excluded(path, v)
+			})
+		}
+		if semver.Build(current) == "+incompatible" {
+			// +incompatible versions predate the module's go.mod; the module may
+			// since have been published at the major version's own path.
+			successor := path + "/" + semver.Major(current)
+			sq, ok := successors[successor]
+			if !ok {
+				sq, _ = g.Proxy.QueryLatest(successor, "", nil)
+				successors[successor] = sq
+			}
+			if sq != nil {
+				dep.Notes = append(dep.Notes, "+incompatible version; module path "+successor+" is available (latest "+sq.Version+")")
+			}
+		}
+		if dep.Replace == "" {
+			key := path + "@" + semver.Major(current)
+			succ, ok := majorSuccessors[key]
+			if !ok {
+				succ.path, succ.query = g.Proxy.QueryMajorSuccessor(path, current)
+				majorSuccessors[key] = succ
+			}
+			if succ.query != nil {
+				dep.Notes = append(dep.Notes, "major upgrade available (new import path): "+succ.path+" "+succ.query.Version)
+			}
+		}
+		if q == nil {
+			continue
+		}
+		if semver.Compare(q.Version, current) >= 0 {
+			dep.Latest = q.Version
+		}
+		if q.GoMod != nil && dep.Replace == "" {
+			if rationale, retracted := q.GoMod.Retracted(current); retracted {
+				dep.Notes = append(dep.Notes, retractedNote(current, rationale))
+			}
+		}
+	}
+	if len(unproxied) > 0 {
+		g.resolveUnproxied(dir, unproxied, reasons)
+	}
+}
+
+// describePseudoVersion shows the commit date of a pseudo-version and notes
+// when Latest is a tagged release newer than the pseudo-version's base.
+func describePseudoVersion(dep *model.Dependency, current string) {
+	if !module.IsPseudoVersion(current) {
+		return
+	}
+	if t, err := module.PseudoVersionTime(current); err == nil {
+		dep.VersionDetail = "committed " + t.UTC().Format("2006-01-02")
+	}
+	if dep.Latest == "" || module.IsPseudoVersion(dep.Latest) || semver.Compare(dep.Latest, current) <= 0 {
+		return
+	}
+	base, _ := module.PseudoVersionBase(current)
+	if base == "" {
+		dep.Notes = append(dep.Notes, "pseudo-version of an untagged commit; tagged release "+dep.Latest+" is available")
+		return
+	}
+	dep.Notes = append(dep.Notes, "tagged release "+dep.Latest+" is newer than the pseudo-version's base "+base)
+}
+
+func retractedNote(version, rationale string) string {
+	note := "version " + version + " is retracted"
+	if rationale != "" {
+		note += ": " + rationale
+	}
+	return note
+}
+
+// Sections reports a go.mod as a single section, and each module of a go.work
+// workspace separately followed by a rollup listing every required module once
+// at the highest version any workspace module requires, which is the version
+// the workspace builds with. Groups of a rollup entry name the modules
+// requiring it. Unless IncludeIndirect is set, indirect requirements are left
+// out and each section counts the outdated ones in its summary.
+func (g *Go) Sections(path string, deps []model.Dependency) []Section {
+	var sections []Section
+	if filepath.Base(path) == "go.work" {
+		sections = goWorkspaceSections(path, deps)
+	} else {
+		sections = []Section{{Title: g.Title(), Dependencies: deps}}
+	}
+	for i := range sections {
+		sections[i] = g.filterIndirect(sections[i])
+	}
+	return sections
+}
+
+func (g *Go) filterIndirect(section Section) Section {
+	direct := []model.Dependency{}
+	outdated := 0
+	for _, dep := range section.Dependencies {
+		if dep.Direct {
+			direct = append(direct, dep)
+		} else if dep.Outdated {
+			outdated++
+		}
+	}
+	section.Summary = fmt.Sprintf("Outdated indirect modules: %d", outdated)
+	if !g.IncludeIndirect {
+		section.Dependencies = direct
+		if outdated > 0 {
+			section.Summary += " (not listed; use `--include-indirect` to show them)"
+		}
+	}
+	return section
+}
+
+func goWorkspaceSections(path string, deps []model.Dependency) []Section {
+	root := filepath.Dir(path)
+	sections := []Section{}
+	index := make(map[string]int)
+	rollup := make(map[string]model.Dependency)
+	for _, dep := range deps {
+		module, err := filepath.Rel(root, filepath.Dir(dep.ManifestPath))
+		if err != nil {
+			module = filepath.Dir(dep.ManifestPath)
+		}
+		i, ok := index[dep.ManifestPath]
+		if !ok {
+			i = len(sections)
+			index[dep.ManifestPath] = i
+			sections = append(sections, Section{Title: "Go workspace (go.work): " + filepath.ToSlash(module)})
+		}
+		sections[i].Dependencies = append(sections[i].Dependencies, dep)
+
+		merged, ok := rollup[dep.Name]
+		if !ok || semver.Compare(dep.Version, merged.Version) > 0 {
+			groups, direct := merged.Groups, merged.Direct
+			merged = dep
+			merged.ManifestPath = path
+			merged.Groups, merged.Direct = groups, direct
+		}
+		merged.Direct = merged.Direct || dep.Direct
+		merged.Groups = append(append([]string{}, merged.Groups...), filepath.ToSlash(module))
+		rollup[dep.Name] = merged
+	}
+
+	all := make([]model.Dependency, 0, len(rollup))
+	for _, dep := range rollup {
+		all = append(all, dep)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return append(sections, Section{Title: "Go workspace (go.work): all modules", Dependencies: all})
+}
+
+// FetchChangelog reads the changelog of modules hosted on GitHub, whose
+// repository follows from the module path. Other modules have no changelog.
+func (*Go) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	repoURL := goModuleRepoURL(name)
+	if repoURL == "" {
+		return nil, nil
+	}
+	return check.ChangelogInfoFromRepo(name, repoURL, current, latest), nil
+}
+
+// goModuleRepoURL returns the GitHub repository of a module such as
+// github.com/owner/repo/sub/v2, or "" for modules hosted elsewhere.
+func goModuleRepoURL(modPath string) string {
+	parts := strings.Split(modPath, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return ""
+	}
+	return "https://" + strings.Join(parts[:3], "/")
+}
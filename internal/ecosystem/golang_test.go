@@ -0,0 +1,367 @@
+package ecosystem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestGoSections(t *testing.T) {
+	root := t.TempDir()
+	work := filepath.Join(root, "go.work")
+	api := filepath.Join(root, "api", "go.mod")
+	lib := filepath.Join(root, "lib", "go.mod")
+	deps := []model.Dependency{
+		{Name: "github.com/stretchr/testify", ManifestPath: api, Version: "v1.8.0", Latest: "v1.9.0", Outdated: true},
+		{Name: "golang.org/x/mod", ManifestPath: api, Version: "v0.12.0", Direct: true},
+		{Name: "github.com/stretchr/testify", ManifestPath: lib, Version: "v1.9.0", Latest: "v1.9.0", Direct: true},
+	}
+
+	g := NewGo()
+	g.IncludeIndirect = true
+	sections := g.Sections(work, deps)
+	if len(sections) != 3 {
+		t.Fatalf("expected 2 module sections and a rollup, got %d", len(sections))
+	}
+	if sections[0].Title != "Go workspace (go.work): api" || len(sections[0].Dependencies) != 2 {
+		t.Errorf("unexpected api section: %+v", sections[0])
+	}
+	rollup := sections[2].Dependencies
+	if len(rollup) != 2 {
+		t.Fatalf("expected 2 deduplicated modules, got %+v", rollup)
+	}
+	testify := rollup[0]
+	if testify.Version != "v1.9.0" || testify.Outdated || !testify.Direct || len(testify.Groups) != 2 {
+		t.Errorf("unexpected rollup entry: %+v", testify)
+	}
+}
+
+func TestGoSections_Indirect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go.mod")
+	deps := []model.Dependency{
+		{Name: "github.com/stretchr/testify", Version: "v1.8.0", Latest: "v1.9.0", Direct: true, Outdated: true},
+		{Name: "github.com/davecgh/go-spew", Version: "v1.1.0", Latest: "v1.1.1", Outdated: true},
+		{Name: "gopkg.in/yaml.v3", Version: "v3.0.1", Latest: "v3.0.1"},
+	}
+
+	g := NewGo()
+	sections := g.Sections(path, deps)
+	if len(sections) != 1 || sections[0].Title != "Go (go.mod)" {
+		t.Fatalf("expected a single go.mod section, got %+v", sections)
+	}
+	if len(sections[0].Dependencies) != 1 || sections[0].Dependencies[0].Name != "github.com/stretchr/testify" {
+		t.Errorf("expected only direct requirements, got %+v", sections[0].Dependencies)
+	}
+	if want := "Outdated indirect modules: 1 (not listed; use `--include-indirect` to show them)"; sections[0].Summary != want {
+		t.Errorf("unexpected summary %q", sections[0].Summary)
+	}
+
+	g.IncludeIndirect = true
+	sections = g.Sections(path, deps)
+	if len(sections[0].Dependencies) != 3 || sections[0].Summary != "Outdated indirect modules: 1" {
+		t.Errorf("expected all requirements with a count, got %+v", sections[0])
+	}
+}
+
+func TestGoResolveLatest_ReplaceAndRetract(t *testing.T) {
+	g := &Go{
+		VersionChecker: func(dir string) (map[string]string, error) {
+			return map[string]string{
+				"github.com/pkg/errors":      "v1.0.0",
+				"github.com/fork/errors":     "v0.9.3",
+				"github.com/sirupsen/logrus": "v1.9.3",
+				"golang.org/x/text":          "v0.15.0",
+			}, nil
+		},
+		RetractionChecker: func(dir string) (map[string]string, error) {
+			return map[string]string{"golang.org/x/text": "security issue"}, nil
+		},
+	}
+	deps := []model.Dependency{
+		{Name: "github.com/pkg/errors", Version: "v0.9.2", Replace: "github.com/fork/errors v0.9.2"},
+		{Name: "github.com/sirupsen/logrus", Version: "v1.9.0", Replace: "../logrus"},
+		{Name: "golang.org/x/text", Version: "v0.14.0"},
+	}
+	if err := g.ResolveLatest(t.TempDir(), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Latest != "v0.9.3" {
+		t.Errorf("expected the replacement's latest version, got %+v", deps[0])
+	}
+	if deps[1].Latest != "" {
+		t.Errorf("expected no latest version for a directory replacement, got %+v", deps[1])
+	}
+	if deps[2].Latest != "v0.15.0" || len(deps[2].Notes) != 1 || deps[2].Notes[0] != "version v0.14.0 is retracted: security issue" {
+		t.Errorf("unexpected retraction handling: %+v", deps[2])
+	}
+}
+
+func TestGoResolveLatest_Proxy(t *testing.T) {
+	proxy := t.TempDir()
+	files := map[string]string{
+		"github.com/pkg/errors/@v/list":             "v0.8.0\nv0.9.0\nv0.9.1\n",
+		"github.com/pkg/errors/@v/v0.9.1.mod":       "module github.com/pkg/errors\n\nretract v0.8.0 // data race\n",
+		"github.com/fork/errors/@v/list":            "v0.9.2\nv0.9.3\n",
+		"github.com/sirupsen/logrus/@v/list":        "v1.9.0\n",
+		"github.com/stretchr/testify/@v/list":       "v1.8.0\n",
+		"github.com/stretchr/testify/@v/v1.8.0.mod": "module github.com/stretchr/testify\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(proxy, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	gomod := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(gomod, []byte("module example.com/app\n\nexclude github.com/pkg/errors v0.9.1\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	g := &Go{Proxy: &check.GoProxy{GOPROXY: "file://" + filepath.ToSlash(proxy)}}
+	deps := []model.Dependency{
+		{Name: "github.com/pkg/errors", ManifestPath: gomod, Version: "v0.8.0"},
+		{Name: "github.com/pkg/errors", ManifestPath: gomod, Version: "v0.9.2", Replace: "github.com/fork/errors v0.9.2"},
+		{Name: "github.com/stretchr/testify", ManifestPath: gomod, Version: "v1.9.0"},
+		{Name: "example.com/missing", ManifestPath: gomod, Version: "v1.0.0"},
+	}
+	if err := g.ResolveLatest(filepath.Dir(gomod), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Latest != "v0.9.0" || len(deps[0].Notes) != 1 || deps[0].Notes[0] != "version v0.8.0 is retracted: data race" {
+		t.Errorf("expected excluded v0.9.1 to be skipped and v0.8.0 flagged, got %+v", deps[0])
+	}
+	if deps[1].Latest != "v0.9.3" {
+		t.Errorf("expected the replacement's latest version, got %+v", deps[1])
+	}
+	if deps[2].Latest != "" {
+		t.Errorf("expected no latest version older than the current one, got %+v", deps[2])
+	}
+	if deps[3].Latest != "" {
+		t.Errorf("expected unknown module to be left untouched, got %+v", deps[3])
+	}
+}
+
+func TestGoResolveLatest_PseudoAndIncompatible(t *testing.T) {
+	proxy := t.TempDir()
+	files := map[string]string{
+		"example.com/untagged/@v/list":         "",
+		"example.com/untagged/@latest":         `{"Version":"v0.0.0-20240301090000-0123456789ab"}`,
+		"example.com/tagged/@v/list":           "v1.4.0\nv1.5.0\n",
+		"example.com/fresh/@v/list":            "v0.1.0\n",
+		"github.com/docker/docker/@v/list":     "v1.13.1\nv20.10.0+incompatible\nv24.0.7+incompatible\n",
+		"github.com/docker/docker/v24/@v/list": "",
+		"github.com/go-redis/redis/@v/list":    "v6.15.9+incompatible\n",
+		"github.com/go-redis/redis/v6/@v/list": "v6.15.9\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(proxy, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	gomod := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(gomod, []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	g := &Go{Proxy: &check.GoProxy{GOPROXY: "file://" + filepath.ToSlash(proxy)}}
+	deps := []model.Dependency{
+		{Name: "example.com/untagged", ManifestPath: gomod, Version: "v0.0.0-20240101120000-abcdef123456"},
+		{Name: "example.com/tagged", ManifestPath: gomod, Version: "v1.4.1-0.20240101120000-abcdef123456"},
+		{Name: "example.com/fresh", ManifestPath: gomod, Version: "v0.0.0-20240101120000-abcdef123456"},
+		{Name: "github.com/docker/docker", ManifestPath: gomod, Version: "v20.10.0+incompatible"},
+		{Name: "github.com/go-redis/redis", ManifestPath: gomod, Version: "v6.15.9+incompatible"},
+	}
+	if err := g.ResolveLatest(filepath.Dir(gomod), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].VersionDetail != "committed 2024-01-01" || deps[0].Latest != "v0.0.0-20240301090000-0123456789ab" || len(deps[0].Notes) != 0 {
+		t.Errorf("unexpected untagged module: %+v", deps[0])
+	}
+	if deps[1].Latest != "v1.5.0" || len(deps[1].Notes) != 1 || deps[1].Notes[0] != "tagged release v1.5.0 is newer than the pseudo-version's base v1.4.0" {
+		t.Errorf("unexpected tagged module: %+v", deps[1])
+	}
+	if len(deps[2].Notes) != 1 || deps[2].Notes[0] != "pseudo-version of an untagged commit; tagged release v0.1.0 is available" {
+		t.Errorf("unexpected freshly tagged module: %+v", deps[2])
+	}
+	if deps[3].Latest != "v24.0.7+incompatible" || len(deps[3].Notes) != 0 {
+		t.Errorf("unexpected +incompatible module without a /vN path: %+v", deps[3])
+	}
+	if len(deps[4].Notes) != 1 || deps[4].Notes[0] != "+incompatible version; module path github.com/go-redis/redis/v6 is available (latest v6.15.9)" {
+		t.Errorf("unexpected +incompatible module with a /vN path: %+v", deps[4])
+	}
+}
+
+func TestGoResolveLatest_MajorSuccessor(t *testing.T) {
+	proxy := t.TempDir()
+	files := map[string]string{
+		"github.com/foo/bar/v2/@v/list": "v2.3.0\nv2.4.0\n",
+		"github.com/foo/bar/v3/@v/list": "v3.0.0\nv3.1.0\n",
+		"github.com/foo/baz/@v/list":    "v1.0.0\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(proxy, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	gomod := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(gomod, []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	g := &Go{Proxy: &check.GoProxy{GOPROXY: "file://" + filepath.ToSlash(proxy)}}
+	deps := []model.Dependency{
+		{Name: "github.com/foo/bar/v2", ManifestPath: gomod, Version: "v2.3.0"},
+		{Name: "github.com/foo/baz", ManifestPath: gomod, Version: "v1.0.0"},
+	}
+	if err := g.ResolveLatest(filepath.Dir(gomod), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Latest != "v2.4.0" || len(deps[0].Notes) != 1 || deps[0].Notes[0] != "major upgrade available (new import path): github.com/foo/bar/v3 v3.1.0" {
+		t.Errorf("unexpected successor handling: %+v", deps[0])
+	}
+	if len(deps[1].Notes) != 0 {
+		t.Errorf("unexpected notes without a successor: %+v", deps[1])
+	}
+}
+
+func TestGoResolveLatest_Unconfigured(t *testing.T) {
+	deps := []model.Dependency{{Name: "github.com/pkg/errors", Version: "v0.9.1"}}
+	if err := (&Go{}).ResolveLatest(t.TempDir(), deps); err == nil {
+		t.Error("expected an error without a proxy or version checker")
+	}
+}
+
+func TestGoResolveLatest_ProxyListsSharedModulesOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/github.com/pkg/errors/@v/list":
+			w.Write([]byte("v0.8.0\nv0.9.0\nv0.9.1\n"))
+		case "/github.com/pkg/errors/@v/v0.9.1.mod":
+			w.Write([]byte("module github.com/pkg/errors\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	manifests := map[string]string{
+		"a/go.mod": "module example.com/a\n",
+		"b/go.mod": "module example.com/b\n\nexclude github.com/pkg/errors v0.9.1\n",
+	}
+	deps := []model.Dependency{}
+	for name, content := range manifests {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		deps = append(deps, model.Dependency{Name: "github.com/pkg/errors", ManifestPath: path, Version: "v0.8.0"})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].ManifestPath < deps[j].ManifestPath })
+
+	g := &Go{Proxy: &check.GoProxy{GOPROXY: ts.URL}}
+	if err := g.ResolveLatest(dir, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Latest != "v0.9.1" || deps[1].Latest != "v0.9.0" {
+		t.Errorf("expected each go.mod's excludes to apply, got %s and %s", deps[0].Latest, deps[1].Latest)
+	}
+	if requests["/github.com/pkg/errors/@v/list"] != 1 || requests["/github.com/pkg/errors/@v/v0.9.1.mod"] != 1 {
+		t.Errorf("expected the module to be fetched once, got %v", requests)
+	}
+}
+
+func TestGoResolveLatest_Unproxied(t *testing.T) {
+	proxy := t.TempDir()
+	list := filepath.Join(proxy, "github.com", "pkg", "errors", "@v", "list")
+	if err := os.MkdirAll(filepath.Dir(list), 0755); err != nil {
+		t.Fatalf("failed to create proxy: %v", err)
+	}
+	if err := os.WriteFile(list, []byte("v0.9.1\n"), 0644); err != nil {
+		t.Fatalf("failed to write list: %v", err)
+	}
+	gomod := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(gomod, []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	newDeps := func() []model.Dependency {
+		return []model.Dependency{
+			{Name: "github.com/pkg/errors", ManifestPath: gomod, Version: "v0.9.0"},
+			{Name: "corp.example.com/lib", ManifestPath: gomod, Version: "v1.0.0"},
+		}
+	}
+	proxyClient := &check.GoProxy{GOPROXY: "file://" + filepath.ToSlash(proxy), GONOPROXY: "corp.example.com"}
+
+	g := &Go{
+		Proxy: proxyClient,
+		VersionChecker: func(dir string) (map[string]string, error) {
+			return map[string]string{"corp.example.com/lib": "v1.2.0", "github.com/pkg/errors": "v0.9.9"}, nil
+		},
+		RetractionChecker: func(dir string) (map[string]string, error) {
+			return map[string]string{"corp.example.com/lib": "broken"}, nil
+		},
+	}
+	deps := newDeps()
+	if err := g.ResolveLatest(filepath.Dir(gomod), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Latest != "v0.9.1" {
+		t.Errorf("expected the proxied module to come from the proxy, got %+v", deps[0])
+	}
+	if deps[1].Latest != "v1.2.0" || len(deps[1].Notes) != 1 || deps[1].Notes[0] != "version v1.0.0 is retracted: broken" {
+		t.Errorf("expected the GONOPROXY module to be checked with the version checker, got %+v", deps[1])
+	}
+
+	g = &Go{Proxy: proxyClient}
+	deps = newDeps()
+	if err := g.ResolveLatest(filepath.Dir(gomod), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[1].Latest != "" || len(deps[1].Notes) != 1 || deps[1].Notes[0] != "not checked: matches GONOPROXY" {
+		t.Errorf("expected the GONOPROXY module to be flagged as not checked, got %+v", deps[1])
+	}
+}
+
+func TestGoModuleRepoURL(t *testing.T) {
+	cases := map[string]string{
+		"github.com/BurntSushi/toml":               "https://github.com/BurntSushi/toml",
+		"github.com/Azure/azure-sdk-for-go/sdk/v2": "https://github.com/Azure/azure-sdk-for-go",
+		"golang.org/x/mod":                         "",
+		"gopkg.in/yaml.v3":                         "",
+		"github.com/incomplete":                    "",
+	}
+	for path, want := range cases {
+		if got := goModuleRepoURL(path); got != want {
+			t.Errorf("goModuleRepoURL(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if info, err := (&Go{}).FetchChangelog("golang.org/x/mod", "v0.17.0", "v0.18.0"); info != nil || err != nil {
+		t.Errorf("expected no changelog for a module outside GitHub, got %+v (err %v)", info, err)
+	}
+}
@@ -0,0 +1,45 @@
+package ecosystem
+
+import (
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Gradle handles Gradle lock files, version catalogs and build scripts.
+// Modules are looked up in a Maven repository.
+type Gradle struct{}
+
+// NewGradle returns the Gradle ecosystem.
+func NewGradle() *Gradle { return &Gradle{} }
+
+func (*Gradle) Name() string  { return "gradle" }
+func (*Gradle) Title() string { return "Java (Gradle)" }
+
+// LockFiles lists the Gradle files in order of preference: lock files record
+// exact versions, the catalog and build scripts only declared ones.
+func (*Gradle) LockFiles() []string {
+	files := append([]string{}, parse.GradleLockFiles...)
+	files = append(files, parse.GradleVersionCatalog)
+	return append(files, parse.GradleBuildFiles...)
+}
+
+func (g *Gradle) Detect(dir string) (string, error) {
+	return detectLockFile(dir, g.LockFiles()...)
+}
+
+func (*Gradle) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseGradleProject(path)
+}
+
+func (*Gradle) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveMavenLatest(deps)
+	return nil
+}
+
+func (*Gradle) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	groupID, artifactID, _ := strings.Cut(name, ":")
+	return check.FetchMavenChangelogInfo(groupID, artifactID, current, latest)
+}
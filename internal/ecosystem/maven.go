@@ -0,0 +1,56 @@
+package ecosystem
+
+import (
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Maven handles pom.xml files. Artifacts are looked up in a Maven repository.
+type Maven struct{}
+
+// NewMaven returns the Maven ecosystem.
+func NewMaven() *Maven { return &Maven{} }
+
+func (*Maven) Name() string        { return "maven" }
+func (*Maven) Title() string       { return "Java (pom.xml)" }
+func (*Maven) LockFiles() []string { return []string{"pom.xml"} }
+
+func (m *Maven) Detect(dir string) (string, error) {
+	return detectLockFile(dir, m.LockFiles()...)
+}
+
+func (*Maven) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParsePomFile(path)
+}
+
+func (*Maven) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveMavenLatest(deps)
+	return nil
+}
+
+func (*Maven) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	groupID, artifactID, _ := strings.Cut(name, ":")
+	return check.FetchMavenChangelogInfo(groupID, artifactID, current, latest)
+}
+
+// resolveMavenLatest looks up every groupId:artifactId in the Maven repository,
+// skipping artifacts the repository could not answer for.
+func resolveMavenLatest(deps []model.Dependency) {
+	latest := make(map[string]string)
+	for i := range deps {
+		name := deps[i].Name
+		v, ok := latest[name]
+		if !ok {
+			if groupID, artifactID, found := strings.Cut(name, ":"); found {
+				v, _ = check.GetMavenLatestVersion(groupID, artifactID)
+			}
+			latest[name] = v
+		}
+		if v != "" {
+			deps[i].Latest = v
+		}
+	}
+}
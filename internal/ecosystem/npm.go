@@ -0,0 +1,95 @@
+package ecosystem
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+	"github.com/cyber-kamil/depflow/internal/version"
+)
+
+// Npm handles package-lock.json files written by npm.
+type Npm struct{}
+
+// NewNpm returns the npm ecosystem.
+func NewNpm() *Npm { return &Npm{} }
+
+func (*Npm) Name() string        { return "npm" }
+func (*Npm) Title() string       { return "NPM (package-lock.json)" }
+func (*Npm) LockFiles() []string { return []string{"package-lock.json"} }
+
+func (n *Npm) Detect(dir string) (string, error) {
+	return detectLockFile(dir, n.LockFiles()...)
+}
+
+func (*Npm) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseNpmLockFile(path)
+}
+
+func (*Npm) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveNpmLatest(deps)
+	return nil
+}
+
+func (*Npm) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchChangelogInfo(name, current, latest)
+}
+
+// resolveNpmLatest looks up every dependency in the npm registry, skipping
+// packages the registry could not answer for. Each package is fetched once.
+// Latest is taken from the dist-tag the package tracks, see
+// check.NpmDistTagFor, and the other dist-tags pointing at a newer version are
+// listed in Channels. Wanted is set to the newest version satisfying the
+// declared range.
+func resolveNpmLatest(deps []model.Dependency) {
+	docs := make(map[string]*check.NpmPackument)
+	for i := range deps {
+		dep := &deps[i]
+		doc, ok := docs[dep.Name]
+		if !ok {
+			doc, _ = check.GetNpmPackage(dep.Name)
+			docs[dep.Name] = doc
+		}
+		if doc == nil {
+			continue
+		}
+		tag := check.NpmDistTagFor(dep.Name)
+		if v, ok := doc.DistTags[tag]; ok && v != "" {
+			dep.Latest = v
+			if tag != "latest" {
+				dep.Channel = tag
+			}
+		} else {
+			dep.Notes = append(dep.Notes, fmt.Sprintf("dist-tag %q not found", tag))
+		}
+		dep.Channels = newerNpmDistTags(doc.DistTags, tag, dep.Version)
+		if dep.Range == "" {
+			continue
+		}
+		if r, err := version.ParseNpmRange(dep.Range); err == nil {
+			dep.Wanted = r.MaxSatisfying(doc.VersionList())
+		}
+	}
+}
+
+// newerNpmDistTags returns the dist-tags other than tracked that point at a
+// version newer than current, sorted by tag.
+func newerNpmDistTags(distTags map[string]string, tracked, current string) []string {
+	tags := make([]string, 0, len(distTags))
+	for tag := range distTags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	var newer []string
+	for _, tag := range tags {
+		if tag == tracked {
+			continue
+		}
+		if c, ok := version.SemVer.Compare(current, distTags[tag]); ok && c < 0 {
+			newer = append(newer, tag+": "+distTags[tag])
+		}
+	}
+	return newer
+}
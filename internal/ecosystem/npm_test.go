@@ -0,0 +1,113 @@
+package ecosystem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestResolveNpmLatest_Wanted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lodash" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"dist-tags":{"latest":"5.0.0"},"versions":{"4.17.20":{},"4.17.21":{},"4.18.0":{},"5.0.0":{}}}`))
+	}))
+	defer ts.Close()
+	old := check.NpmRegistryURL
+	check.NpmRegistryURL = ts.URL + "/"
+	defer func() { check.NpmRegistryURL = old }()
+
+	deps := []model.Dependency{
+		{Name: "lodash", Range: "~4.17.0", Version: "4.17.20"},
+		{Name: "lodash", Range: "github:lodash/lodash", Version: "4.17.20"},
+		{Name: "missing", Range: "^1.0.0", Version: "1.0.0"},
+	}
+	resolveNpmLatest(deps)
+	if deps[0].Latest != "5.0.0" || deps[0].Wanted != "4.17.21" {
+		t.Errorf("unexpected lodash: %+v", deps[0])
+	}
+	if deps[1].Latest != "5.0.0" || deps[1].Wanted != "" {
+		t.Errorf("unexpected wanted for a non-semver range: %+v", deps[1])
+	}
+	if deps[2].Latest != "" || deps[2].Wanted != "" {
+		t.Errorf("unexpected missing package: %+v", deps[2])
+	}
+}
+
+func TestResolveNpmLatest_DistTags(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"dist-tags":{"latest":"18.2.0","next":"19.0.0-rc.1","lts":"16.14.0","canary":"19.1.0-canary"},"versions":{}}`))
+	}))
+	defer ts.Close()
+	oldURL, oldTags := check.NpmRegistryURL, check.NpmPackageDistTags
+	check.NpmRegistryURL = ts.URL + "/"
+	check.NpmPackageDistTags = map[string]string{"react-dom": "next", "scheduler": "beta"}
+	defer func() { check.NpmRegistryURL, check.NpmPackageDistTags = oldURL, oldTags }()
+
+	deps := []model.Dependency{
+		{Name: "react", Version: "17.0.2"},
+		{Name: "react-dom", Version: "18.2.0"},
+		{Name: "scheduler", Version: "0.23.0"},
+	}
+	resolveNpmLatest(deps)
+	if deps[0].Latest != "18.2.0" || deps[0].Channel != "" || strings.Join(deps[0].Channels, ", ") != "canary: 19.1.0-canary, next: 19.0.0-rc.1" {
+		t.Errorf("unexpected react: %+v", deps[0])
+	}
+	if deps[1].Latest != "19.0.0-rc.1" || deps[1].Channel != "next" || strings.Join(deps[1].Channels, ", ") != "canary: 19.1.0-canary" {
+		t.Errorf("unexpected react-dom: %+v", deps[1])
+	}
+	if deps[2].Latest != "" || len(deps[2].Notes) != 1 || deps[2].Notes[0] != `dist-tag "beta" not found` {
+		t.Errorf("unexpected scheduler: %+v", deps[2])
+	}
+}
+
+func TestResolveNpmLatest_AliasedWanted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/string-width" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"dist-tags":{"latest":"7.1.0"},"versions":{"4.2.0":{},"4.2.3":{},"5.1.2":{},"7.1.0":{}}}`))
+	}))
+	defer ts.Close()
+	old := check.NpmRegistryURL
+	check.NpmRegistryURL = ts.URL + "/"
+	defer func() { check.NpmRegistryURL = old }()
+
+	locks := map[string]struct {
+		eco  Ecosystem
+		file string
+		lock string
+	}{
+		"npm": {NewNpm(), "package-lock.json", `{"lockfileVersion": 3, "packages": {
+			"": {"dependencies": {"string-width-cjs": "npm:string-width@^4.2.0"}},
+			"node_modules/string-width-cjs": {"name": "string-width", "version": "4.2.0"}}}`},
+		"bun": {NewBun(), "bun.lock", `{"lockfileVersion": 1,
+			"workspaces": {"": {"dependencies": {"string-width-cjs": "npm:string-width@^4.2.0"}}},
+			"packages": {"string-width-cjs": ["string-width@4.2.0", "", {}, "sha512-sw"]}}`},
+	}
+	for name, l := range locks {
+		path := filepath.Join(t.TempDir(), l.file)
+		if err := os.WriteFile(path, []byte(l.lock), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", l.file, err)
+		}
+		deps, err := l.eco.Parse(path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if err := l.eco.ResolveLatest(filepath.Dir(path), deps); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if len(deps) != 1 || deps[0].Name != "string-width" || deps[0].Range != "^4.2.0" || deps[0].Wanted != "4.2.3" || deps[0].Latest != "7.1.0" {
+			t.Errorf("%s: unexpected aliased dependency: %+v", name, deps)
+		}
+	}
+}
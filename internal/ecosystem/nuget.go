@@ -0,0 +1,64 @@
+package ecosystem
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// NuGet handles .NET projects through packages.lock.json or, without a lock
+// file, the PackageReference items of a .csproj. Packages are looked up in the
+// flat container of a NuGet v3 feed.
+type NuGet struct{}
+
+// NewNuGet returns the NuGet ecosystem.
+func NewNuGet() *NuGet { return &NuGet{} }
+
+func (*NuGet) Name() string        { return "nuget" }
+func (*NuGet) Title() string       { return ".NET (NuGet)" }
+func (*NuGet) LockFiles() []string { return []string{"packages.lock.json"} }
+
+// Detect prefers packages.lock.json and falls back to the first .csproj in dir.
+func (n *NuGet) Detect(dir string) (string, error) {
+	path, err := detectLockFile(dir, n.LockFiles()...)
+	if path != "" || err != nil {
+		return path, err
+	}
+	projects, err := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list project files: %w", err)
+	}
+	if len(projects) == 0 {
+		return "", nil
+	}
+	sort.Strings(projects)
+	return detectLockFile(dir, filepath.Base(projects[0]))
+}
+
+func (*NuGet) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseNuGetProject(path)
+}
+
+func (*NuGet) ResolveLatest(dir string, deps []model.Dependency) error {
+	latest := make(map[string]string)
+	for i := range deps {
+		name := deps[i].Name
+		v, ok := latest[name]
+		if !ok {
+			v, _ = check.GetNuGetLatestVersion(name)
+			latest[name] = v
+		}
+		if v != "" {
+			deps[i].Latest = v
+		}
+	}
+	return nil
+}
+
+func (*NuGet) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchNuGetChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,78 @@
+package ecosystem
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Pip handles pip requirements files. Packages are looked up in the PyPI JSON API.
+type Pip struct{}
+
+// NewPip returns the pip ecosystem.
+func NewPip() *Pip { return &Pip{} }
+
+func (*Pip) Name() string        { return "pip" }
+func (*Pip) Title() string       { return "Python (requirements.txt)" }
+func (*Pip) LockFiles() []string { return []string{"requirements.txt"} }
+
+func (p *Pip) Detect(dir string) (string, error) {
+	return detectLockFile(dir, p.LockFiles()...)
+}
+
+func (*Pip) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseRequirementsFile(path)
+}
+
+func (*Pip) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolvePyPILatest(deps)
+	return nil
+}
+
+func (*Pip) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchPyPIChangelogInfo(name, current, latest)
+}
+
+// resolvePyPILatest looks up every dependency on PyPI, skipping packages the
+// index could not answer for. Each normalized name is fetched once. Packages
+// locked from another index, such as a private Poetry source, are not looked
+// up since PyPI may have an unrelated project of the same name.
+func resolvePyPILatest(deps []model.Dependency) {
+	latest := make(map[string]string)
+	for i := range deps {
+		if !pypiServesRegistry(deps[i].Registry) {
+			deps[i].Notes = append(deps[i].Notes, "not checked: installed from "+deps[i].Registry)
+			continue
+		}
+		name := parse.NormalizePythonName(deps[i].Name)
+		v, ok := latest[name]
+		if !ok {
+			v, _ = check.GetPyPILatestVersion(name)
+			latest[name] = v
+		}
+		if v != "" {
+			deps[i].Latest = v
+		}
+	}
+}
+
+// pypiServesRegistry reports whether a locked package index is PyPI itself or
+// the index check.PyPIIndexURL points at. An empty registry means PyPI.
+func pypiServesRegistry(registry string) bool {
+	if registry == "" {
+		return true
+	}
+	u, err := url.Parse(registry)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "pypi.org" {
+		return true
+	}
+	index, err := url.Parse(check.PyPIIndexURL)
+	return err == nil && strings.EqualFold(index.Hostname(), host)
+}
@@ -0,0 +1,65 @@
+package ecosystem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/check"
+)
+
+func TestPoetryResolveLatest_LegacySource(t *testing.T) {
+	var hits []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, r.URL.Path)
+		w.Write([]byte(`{"info":{"name":"x","version":"9.9.9"}}`))
+	}))
+	defer ts.Close()
+	old := check.PyPIIndexURL
+	check.PyPIIndexURL = ts.URL + "/"
+	defer func() { check.PyPIIndexURL = old }()
+
+	dir := t.TempDir()
+	lock := filepath.Join(dir, "poetry.lock")
+	content := `[[package]]
+name = "internal-auth"
+version = "1.2.0"
+
+[package.source]
+type = "legacy"
+url = "https://pypi.example.com/simple"
+reference = "private"
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+`
+	if err := os.WriteFile(lock, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write poetry.lock: %v", err)
+	}
+	e := NewPoetry()
+	deps, err := e.Parse(lock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.ResolveLatest(dir, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, dep := range deps {
+		switch dep.Name {
+		case "internal-auth":
+			if dep.Latest != "" || len(dep.Notes) != 1 || dep.Notes[0] != "not checked: installed from https://pypi.example.com/simple" {
+				t.Errorf("expected the legacy-source package to be skipped: %+v", dep)
+			}
+		case "requests":
+			if dep.Latest != "9.9.9" {
+				t.Errorf("expected requests to be looked up on PyPI: %+v", dep)
+			}
+		}
+	}
+	if len(hits) != 1 || hits[0] != "/pypi/requests/json" {
+		t.Errorf("expected only requests to be fetched, got %v", hits)
+	}
+}
@@ -0,0 +1,34 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Pipenv handles Pipfile.lock files. Packages are looked up in the PyPI JSON API.
+type Pipenv struct{}
+
+// NewPipenv returns the Pipenv ecosystem.
+func NewPipenv() *Pipenv { return &Pipenv{} }
+
+func (*Pipenv) Name() string        { return "pipenv" }
+func (*Pipenv) Title() string       { return "Python (Pipfile.lock)" }
+func (*Pipenv) LockFiles() []string { return []string{"Pipfile.lock"} }
+
+func (p *Pipenv) Detect(dir string) (string, error) {
+	return detectLockFile(dir, p.LockFiles()...)
+}
+
+func (*Pipenv) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParsePipfileLock(path)
+}
+
+func (*Pipenv) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolvePyPILatest(deps)
+	return nil
+}
+
+func (*Pipenv) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchPyPIChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,34 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Pnpm handles pnpm-lock.yaml files. Packages are looked up in the npm registry.
+type Pnpm struct{}
+
+// NewPnpm returns the pnpm ecosystem.
+func NewPnpm() *Pnpm { return &Pnpm{} }
+
+func (*Pnpm) Name() string        { return "pnpm" }
+func (*Pnpm) Title() string       { return "pnpm (pnpm-lock.yaml)" }
+func (*Pnpm) LockFiles() []string { return []string{"pnpm-lock.yaml"} }
+
+func (p *Pnpm) Detect(dir string) (string, error) {
+	return detectLockFile(dir, p.LockFiles()...)
+}
+
+func (*Pnpm) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParsePnpmLockFile(path)
+}
+
+func (*Pnpm) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveNpmLatest(deps)
+	return nil
+}
+
+func (*Pnpm) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchChangelogInfo(name, current, latest)
+}
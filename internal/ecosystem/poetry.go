@@ -0,0 +1,34 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Poetry handles poetry.lock files. Packages are looked up in the PyPI JSON API.
+type Poetry struct{}
+
+// NewPoetry returns the Poetry ecosystem.
+func NewPoetry() *Poetry { return &Poetry{} }
+
+func (*Poetry) Name() string        { return "poetry" }
+func (*Poetry) Title() string       { return "Python (poetry.lock)" }
+func (*Poetry) LockFiles() []string { return []string{"poetry.lock"} }
+
+func (e *Poetry) Detect(dir string) (string, error) {
+	return detectLockFile(dir, e.LockFiles()...)
+}
+
+func (*Poetry) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParsePoetryLockFile(path)
+}
+
+func (*Poetry) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolvePyPILatest(deps)
+	return nil
+}
+
+func (*Poetry) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchPyPIChangelogInfo(name, current, latest)
+}
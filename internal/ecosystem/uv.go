@@ -0,0 +1,34 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Uv handles uv.lock files. Packages are looked up in the PyPI JSON API.
+type Uv struct{}
+
+// NewUv returns the uv ecosystem.
+func NewUv() *Uv { return &Uv{} }
+
+func (*Uv) Name() string        { return "uv" }
+func (*Uv) Title() string       { return "Python (uv.lock)" }
+func (*Uv) LockFiles() []string { return []string{"uv.lock"} }
+
+func (e *Uv) Detect(dir string) (string, error) {
+	return detectLockFile(dir, e.LockFiles()...)
+}
+
+func (*Uv) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseUvLockFile(path)
+}
+
+func (*Uv) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolvePyPILatest(deps)
+	return nil
+}
+
+func (*Uv) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchPyPIChangelogInfo(name, current, latest)
+}
@@ -0,0 +1,78 @@
+package ecosystem
+
+import (
+	"github.com/cyber-kamil/depflow/internal/check"
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/parse"
+)
+
+// Yarn handles yarn.lock files written by Yarn classic (v1). Packages are
+// looked up in the npm registry.
+type Yarn struct{}
+
+// NewYarn returns the Yarn classic ecosystem.
+func NewYarn() *Yarn { return &Yarn{} }
+
+func (*Yarn) Name() string        { return "yarn" }
+func (*Yarn) Title() string       { return "Yarn (yarn.lock)" }
+func (*Yarn) LockFiles() []string { return []string{"yarn.lock"} }
+
+func (y *Yarn) Detect(dir string) (string, error) {
+	return detectYarnLockFile(dir, false)
+}
+
+func (*Yarn) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseYarnLockFile(path)
+}
+
+func (*Yarn) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveNpmLatest(deps)
+	return nil
+}
+
+func (*Yarn) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchChangelogInfo(name, current, latest)
+}
+
+// YarnBerry handles yarn.lock files written by Yarn 2 and newer.
+type YarnBerry struct{}
+
+// NewYarnBerry returns the Yarn Berry ecosystem.
+func NewYarnBerry() *YarnBerry { return &YarnBerry{} }
+
+func (*YarnBerry) Name() string        { return "yarn-berry" }
+func (*YarnBerry) Title() string       { return "Yarn Berry (yarn.lock)" }
+func (*YarnBerry) LockFiles() []string { return []string{"yarn.lock"} }
+
+func (*YarnBerry) Detect(dir string) (string, error) {
+	return detectYarnLockFile(dir, true)
+}
+
+func (*YarnBerry) Parse(path string) ([]model.Dependency, error) {
+	return parse.ParseYarnBerryLockFile(path)
+}
+
+func (*YarnBerry) ResolveLatest(dir string, deps []model.Dependency) error {
+	resolveNpmLatest(deps)
+	return nil
+}
+
+func (*YarnBerry) FetchChangelog(name, current, latest string) (*model.ChangelogInfo, error) {
+	return check.FetchChangelogInfo(name, current, latest)
+}
+
+// detectYarnLockFile returns the yarn.lock in dir if its format matches berry.
+func detectYarnLockFile(dir string, berry bool) (string, error) {
+	path, err := detectLockFile(dir, "yarn.lock")
+	if err != nil || path == "" {
+		return path, err
+	}
+	isBerry, err := parse.IsYarnBerryLockFile(path)
+	if err != nil {
+		return "", err
+	}
+	if isBerry != berry {
+		return "", nil
+	}
+	return path, nil
+}
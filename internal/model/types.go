@@ -6,3 +6,40 @@ type ChangelogInfo struct {
 	ChangelogURL string
 	Highlights   []string // e.g., breaking changes or summary lines
 }
+
+// Scope tells whether a dependency is needed at runtime or only for development.
+type Scope string
+
+const (
+	ScopeProd Scope = "prod"
+	ScopeDev  Scope = "dev"
+)
+
+// Dependency is a single resolved dependency read from a lock file or manifest.
+// Parsers fill in what their format records; checkers fill in Latest, Wanted and Outdated.
+type Dependency struct {
+	Ecosystem     string // registered ecosystem name, e.g. "npm" or "go"
+	Name          string
+	ManifestPath  string // lock file or manifest the dependency was read from
+	Direct        bool   // false for transitive dependencies
+	Scope         Scope
+	Optional      bool
+	Groups        []string // dependency groups declaring it; "extra:<name>" for optional extras
+	Peer          bool     // peer dependency expected to be provided by the consumer
+	Extras        []string // optional features requested, e.g. requests[security]
+	Markers       string   // environment markers limiting where the dependency applies
+	Range         string   // declared version range, e.g. "^4.17.0"
+	Version       string   // resolved (locked) version
+	VersionDetail string   // shown below the version, e.g. the commit date of a Go pseudo-version
+	Latest        string
+	Channel       string   // release channel Latest was taken from when not the default, e.g. npm's "next" dist-tag
+	Channels      []string // other release channels with a newer version, as "tag: version"
+	Wanted        string   // newest version satisfying Range
+	Integrity     string   // integrity hash or checksum recorded in the lock file
+	Registry      string   // source registry or resolved download URL
+	Replace       string   // replacement used instead of the dependency, e.g. a go.mod replace target
+	License       string
+	Outdated      bool
+	UpdateType    string   // "major", "minor" or "patch" for outdated dependencies
+	Notes         []string // warnings shown next to the status, e.g. "yanked"
+}
@@ -0,0 +1,209 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// BunWorkspace is a project listed in the "workspaces" object of bun.lock.
+// The key "" is the root project.
+type BunWorkspace struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// BunLockFile is the text lock file (bun.lock) written by Bun 1.1.39 and newer.
+// Each package is an array of [descriptor, registry, info, integrity] keyed by its
+// install path, e.g. "lodash" or "jest/lodash" for a nested copy.
+type BunLockFile struct {
+	LockfileVersion int                          `json:"lockfileVersion"`
+	Workspaces      map[string]BunWorkspace      `json:"workspaces"`
+	Packages        map[string][]json.RawMessage `json:"packages"`
+}
+
+// ParseBunLockFile parses a bun.lock file and returns the npm packages it locks.
+// Workspace, git and local packages are skipped.
+func ParseBunLockFile(path string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bun.lock: %w", err)
+	}
+	var lock BunLockFile
+	if err := json.Unmarshal(stripJSONC(data), &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse bun.lock: %w", err)
+	}
+
+	deps := []model.Dependency{}
+	for key, entry := range lock.Packages {
+		if len(entry) == 0 {
+			continue
+		}
+		var descriptor string
+		if err := json.Unmarshal(entry[0], &descriptor); err != nil {
+			return nil, fmt.Errorf("failed to parse bun.lock entry %s: %w", key, err)
+		}
+		name, version := splitNpmDescriptor(descriptor)
+		if version == "" || version[0] < '0' || version[0] > '9' {
+			continue
+		}
+		dep := model.Dependency{
+			Ecosystem:    "bun",
+			Name:         name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Version:      version,
+		}
+		if len(entry) > 1 {
+			_ = json.Unmarshal(entry[1], &dep.Registry)
+		}
+		if len(entry) > 3 {
+			_ = json.Unmarshal(entry[3], &dep.Integrity)
+		}
+		if installPath := bunInstallPath(key); len(installPath) == 1 {
+			markBunDirect(&dep, installPath[0], lock.Workspaces)
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// markBunDirect marks dep as direct if a workspace declares it under installName.
+// The root workspace "" is consulted first, then the others by path, so the
+// range and scope do not depend on map order.
+func markBunDirect(dep *model.Dependency, installName string, workspaces map[string]BunWorkspace) {
+	paths := make([]string, 0, len(workspaces))
+	for path := range workspaces {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		ws := workspaces[path]
+		sections := []struct {
+			declared map[string]string
+			scope    model.Scope
+			optional bool
+		}{
+			{ws.Dependencies, model.ScopeProd, false},
+			{ws.DevDependencies, model.ScopeDev, false},
+			{ws.OptionalDependencies, model.ScopeProd, true},
+			{ws.PeerDependencies, model.ScopeProd, false},
+		}
+		for _, section := range sections {
+			if r, ok := section.declared[installName]; ok {
+				dep.Direct = true
+				_, dep.Range = packageJSONRange(installName, r)
+				dep.Scope = section.scope
+				dep.Optional = section.optional
+				return
+			}
+		}
+	}
+}
+
+// bunInstallPath splits a package key into the chain of package names leading
+// to it, keeping scoped names such as "@babel/core" together.
+func bunInstallPath(key string) []string {
+	parts := strings.Split(key, "/")
+	names := []string{}
+	for i := 0; i < len(parts); i++ {
+		if strings.HasPrefix(parts[i], "@") && i+1 < len(parts) {
+			names = append(names, parts[i]+"/"+parts[i+1])
+			i++
+			continue
+		}
+		names = append(names, parts[i])
+	}
+	return names
+}
+
+// splitNpmDescriptor splits "name@version", where name may be scoped.
+func splitNpmDescriptor(s string) (name, version string) {
+	if s == "" {
+		return "", ""
+	}
+	at := strings.Index(s[1:], "@")
+	if at < 0 {
+		return s, ""
+	}
+	return s[:at+1], s[at+2:]
+}
+
+// stripJSONC turns JSON with comments and trailing commas into plain JSON.
+func stripJSONC(data []byte) []byte {
+	return stripTrailingCommas(stripJSONComments(data))
+}
+
+// stripJSONComments removes // and /* */ comments outside of strings.
+func stripJSONComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// stripTrailingCommas removes commas directly followed by a closing bracket.
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		case c == '"':
+			inString = true
+		case c == ',':
+			j := i + 1
+			for j < len(data) && strings.ContainsRune(" \t\r\n", rune(data[j])) {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package parse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParseBunLockFile_Valid(t *testing.T) {
+	lock := `{
+  "lockfileVersion": 1,
+  "workspaces": {
+    "": {
+      "name": "my-app",
+      "dependencies": {
+        "@babel/core": "^7.22.0",
+        "shared": "workspace:*",
+      },
+      "devDependencies": {
+        "typescript": "^5.0.0", // pinned by CI
+      },
+    },
+    "packages/shared": {
+      "name": "shared",
+    },
+  },
+  "packages": {
+    "@babel/core": ["@babel/core@7.22.5", "", { "dependencies": { "debug": "^4.1.0" } }, "sha512-babel"],
+    "debug": ["debug@4.3.4", "", {}, "sha512-debug"],
+    "@babel/core/debug": ["debug@3.2.7", "", {}, "sha512-old-debug"],
+    "shared": ["shared@workspace:packages/shared"],
+    "typescript": ["typescript@5.1.6", "https://npm.example.com/", { "bin": { "tsc": "bin/tsc" } }, "sha512-ts"],
+  }
+}
+`
+	deps, err := ParseBunLockFile(writeTempLock(t, "bun.lock", lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 dependencies, got %+v", deps)
+	}
+	byVersion := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byVersion[d.Name+"@"+d.Version] = d
+	}
+	if babel := byVersion["@babel/core@7.22.5"]; !babel.Direct || babel.Range != "^7.22.0" || babel.Integrity != "sha512-babel" {
+		t.Errorf("unexpected @babel/core: %+v", babel)
+	}
+	if ts := byVersion["typescript@5.1.6"]; !ts.Direct || ts.Scope != model.ScopeDev || ts.Registry != "https://npm.example.com/" {
+		t.Errorf("unexpected typescript: %+v", ts)
+	}
+	if debug := byVersion["debug@3.2.7"]; debug.Direct || debug.Integrity != "sha512-old-debug" {
+		t.Errorf("unexpected nested debug: %+v", debug)
+	}
+	if debug := byVersion["debug@4.3.4"]; debug.Direct {
+		t.Errorf("unexpected debug: %+v", debug)
+	}
+}
+
+func TestMarkBunDirect_RootFirst(t *testing.T) {
+	workspaces := map[string]BunWorkspace{
+		"packages/b": {DevDependencies: map[string]string{"lodash": "^3.0.0"}},
+		"":           {Dependencies: map[string]string{"lodash": "^4.17.0"}},
+		"packages/a": {OptionalDependencies: map[string]string{"lodash": "^2.0.0"}},
+	}
+	for i := 0; i < 20; i++ {
+		var dep model.Dependency
+		markBunDirect(&dep, "lodash", workspaces)
+		if !dep.Direct || dep.Range != "^4.17.0" || dep.Scope != model.ScopeProd || dep.Optional {
+			t.Fatalf("expected the root workspace declaration, got %+v", dep)
+		}
+	}
+	delete(workspaces, "")
+	var dep model.Dependency
+	markBunDirect(&dep, "lodash", workspaces)
+	if dep.Range != "^2.0.0" || !dep.Optional {
+		t.Errorf("expected packages/a to win over packages/b, got %+v", dep)
+	}
+}
+
+func TestParseBunLockFile_MissingFile(t *testing.T) {
+	_, err := ParseBunLockFile("nonexistent.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseBunLockFile_Malformed(t *testing.T) {
+	_, err := ParseBunLockFile(writeTempLock(t, "bun.lock", `{"packages": {"lodash": [4]}}`))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	in := `{"a": "x // not a comment", /* block */ "b": [1, 2,], // line
+}`
+	var out struct {
+		A string `json:"a"`
+		B []int  `json:"b"`
+	}
+	if err := json.Unmarshal(stripJSONC([]byte(in)), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.A != "x // not a comment" || len(out.B) != 2 {
+		t.Errorf("unexpected output: %+v", out)
+	}
+}
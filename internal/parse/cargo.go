@@ -0,0 +1,167 @@
+package parse
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// CargoPackage is a [[package]] entry of Cargo.lock.
+type CargoPackage struct {
+	Name     string `toml:"name"`
+	Version  string `toml:"version"`
+	Source   string `toml:"source"` // registry+URL, sparse+URL or git+URL; empty for path crates
+	Checksum string `toml:"checksum"`
+}
+
+type CargoLockFile struct {
+	Version  int            `toml:"version"`
+	Packages []CargoPackage `toml:"package"`
+}
+
+// IsCargoRegistrySource reports whether a Cargo.lock source is a crate registry.
+func IsCargoRegistrySource(source string) bool {
+	return strings.HasPrefix(source, "registry+") || strings.HasPrefix(source, "sparse+")
+}
+
+// ParseCargoLockFile parses a Cargo.lock file (format v3 or v4) and returns its
+// registry and git crates. Path crates, including workspace members, are skipped.
+// Direct dependencies are read from Cargo.toml next to the lock file and from the
+// manifests of its workspace members.
+func ParseCargoLockFile(path string) ([]model.Dependency, error) {
+	var lock CargoLockFile
+	if _, err := toml.DecodeFile(path, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse Cargo.lock: %w", err)
+	}
+	declared, err := parseCargoWorkspace(filepath.Join(filepath.Dir(path), "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	deps := []model.Dependency{}
+	for _, pkg := range lock.Packages {
+		if pkg.Source == "" {
+			continue
+		}
+		registry := pkg.Source
+		if IsCargoRegistrySource(registry) {
+			registry = registry[strings.Index(registry, "+")+1:]
+		}
+		dep := model.Dependency{
+			Ecosystem:    "cargo",
+			Name:         pkg.Name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Version:      pkg.Version,
+			Integrity:    pkg.Checksum,
+			Registry:     registry,
+		}
+		if d, ok := declared[pkg.Name]; ok {
+			dep.Direct = true
+			dep.Range = d.Range
+			dep.Scope = d.Scope
+			dep.Optional = d.Optional
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+type cargoManifest struct {
+	Dependencies      map[string]interface{} `toml:"dependencies"`
+	DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+	BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	Target            map[string]struct {
+		Dependencies      map[string]interface{} `toml:"dependencies"`
+		DevDependencies   map[string]interface{} `toml:"dev-dependencies"`
+		BuildDependencies map[string]interface{} `toml:"build-dependencies"`
+	} `toml:"target"`
+	Workspace struct {
+		Members      []string               `toml:"members"`
+		Exclude      []string               `toml:"exclude"`
+		Dependencies map[string]interface{} `toml:"dependencies"`
+	} `toml:"workspace"`
+}
+
+// parseCargoWorkspace returns the dependencies declared by the manifest at path and
+// by its workspace members, keyed by crate name. A missing manifest yields no entries.
+func parseCargoWorkspace(path string) (map[string]model.Dependency, error) {
+	declared := make(map[string]model.Dependency)
+	if !fileExists(path) {
+		return declared, nil
+	}
+	var root cargoManifest
+	if _, err := toml.DecodeFile(path, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse Cargo.toml: %w", err)
+	}
+	workspaceDeps := make(map[string]model.Dependency)
+	addCargoDependencies(workspaceDeps, root.Workspace.Dependencies, model.ScopeProd, nil)
+	addCargoManifest(declared, root, workspaceDeps)
+
+	dir := filepath.Dir(path)
+	excluded := make(map[string]bool)
+	for _, ex := range root.Workspace.Exclude {
+		excluded[filepath.Clean(filepath.Join(dir, ex))] = true
+	}
+	for _, pattern := range root.Workspace.Members {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace member pattern %q: %w", pattern, err)
+		}
+		for _, member := range matches {
+			memberPath := filepath.Join(member, "Cargo.toml")
+			if excluded[filepath.Clean(member)] || !fileExists(memberPath) {
+				continue
+			}
+			var manifest cargoManifest
+			if _, err := toml.DecodeFile(memberPath, &manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", memberPath, err)
+			}
+			addCargoManifest(declared, manifest, workspaceDeps)
+		}
+	}
+	return declared, nil
+}
+
+func addCargoManifest(declared map[string]model.Dependency, m cargoManifest, workspaceDeps map[string]model.Dependency) {
+	addCargoDependencies(declared, m.Dependencies, model.ScopeProd, workspaceDeps)
+	addCargoDependencies(declared, m.BuildDependencies, model.ScopeProd, workspaceDeps)
+	addCargoDependencies(declared, m.DevDependencies, model.ScopeDev, workspaceDeps)
+	for _, target := range m.Target {
+		addCargoDependencies(declared, target.Dependencies, model.ScopeProd, workspaceDeps)
+		addCargoDependencies(declared, target.BuildDependencies, model.ScopeProd, workspaceDeps)
+		addCargoDependencies(declared, target.DevDependencies, model.ScopeDev, workspaceDeps)
+	}
+}
+
+// addCargoDependencies records a [dependencies]-style table. Values are a version
+// string or a table that may rename the crate (package = "...") or inherit from
+// [workspace.dependencies] (workspace = true). Runtime declarations win over dev ones.
+func addCargoDependencies(declared map[string]model.Dependency, table map[string]interface{}, scope model.Scope, workspaceDeps map[string]model.Dependency) {
+	for key, value := range table {
+		dep := model.Dependency{Name: key, Scope: scope}
+		switch v := value.(type) {
+		case string:
+			dep.Range = v
+		case map[string]interface{}:
+			if pkg, ok := v["package"].(string); ok {
+				dep.Name = pkg
+			}
+			dep.Range, _ = v["version"].(string)
+			dep.Optional, _ = v["optional"].(bool)
+			if inherit, _ := v["workspace"].(bool); inherit {
+				if ws, ok := workspaceDeps[key]; ok {
+					dep.Name = ws.Name
+					dep.Range = ws.Range
+				}
+			}
+		}
+		if existing, ok := declared[dep.Name]; ok && existing.Scope == model.ScopeProd {
+			continue
+		}
+		declared[dep.Name] = dep
+	}
+}
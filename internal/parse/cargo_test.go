@@ -0,0 +1,142 @@
+package parse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+const cargoLock = `# This file is automatically @generated by Cargo.
+version = 4
+
+[[package]]
+name = "api"
+version = "0.1.0"
+dependencies = ["serde", "tokio"]
+
+[[package]]
+name = "core"
+version = "0.1.0"
+
+[[package]]
+name = "serde"
+version = "1.0.193"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "25dd9975e68d0cb5aa1120c288333fc98731bd1dd12f561e468ea4728c042b89"
+
+[[package]]
+name = "tokio"
+version = "1.35.0"
+source = "sparse+https://index.crates.io/"
+checksum = "841d45b238a16291a4e1584e61820b8ae57d696cc5015c459c229ccc6990cc1c"
+
+[[package]]
+name = "insta"
+version = "1.34.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "mio"
+version = "0.8.10"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "mylib"
+version = "0.2.0"
+source = "git+https://github.com/org/mylib?branch=main#abc123"
+`
+
+func TestParseCargoLockFile_Workspace(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"Cargo.lock": cargoLock,
+		"Cargo.toml": `[workspace]
+members = ["crates/*"]
+exclude = ["crates/skipped"]
+
+[workspace.dependencies]
+serde_crate = { package = "serde", version = "1.0" }
+`,
+		"crates/api/Cargo.toml": `[package]
+name = "api"
+
+[dependencies]
+serde = { workspace = true }
+tokio = { version = "1.35", optional = true }
+mylib = { git = "https://github.com/org/mylib", branch = "main" }
+core = { path = "../core" }
+
+[dev-dependencies]
+insta = "1.34"
+`,
+		"crates/core/Cargo.toml": `[package]
+name = "core"
+`,
+		"crates/skipped/Cargo.toml": `[dependencies]
+mio = "0.8"
+`,
+	})
+
+	deps, err := ParseCargoLockFile(filepath.Join(dir, "Cargo.lock"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 5 {
+		t.Fatalf("expected 5 crates without path sources, got %d: %+v", len(deps), deps)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+
+	serde := byName["serde"]
+	if !serde.Direct || serde.Range != "1.0" || serde.Registry != "https://github.com/rust-lang/crates.io-index" || serde.Integrity == "" {
+		t.Errorf("unexpected serde: %+v", serde)
+	}
+	tokio := byName["tokio"]
+	if !tokio.Direct || !tokio.Optional || tokio.Registry != "https://index.crates.io/" {
+		t.Errorf("unexpected tokio: %+v", tokio)
+	}
+	if insta := byName["insta"]; !insta.Direct || insta.Scope != model.ScopeDev {
+		t.Errorf("expected insta to be a direct dev dependency, got %+v", insta)
+	}
+	if mio := byName["mio"]; mio.Direct {
+		t.Errorf("expected mio from an excluded member to be transitive, got %+v", mio)
+	}
+	if mylib := byName["mylib"]; !mylib.Direct || mylib.Registry != "git+https://github.com/org/mylib?branch=main#abc123" {
+		t.Errorf("unexpected mylib: %+v", mylib)
+	}
+}
+
+func TestParseCargoLockFile_V3WithoutManifest(t *testing.T) {
+	path := writeTempLock(t, "Cargo.lock", `version = 3
+
+[[package]]
+name = "serde"
+version = "1.0.100"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "abc"
+`)
+	deps, err := ParseCargoLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Version != "1.0.100" || deps[0].Direct {
+		t.Errorf("unexpected deps: %+v", deps)
+	}
+}
+
+func TestParseCargoLockFile_FileNotFound(t *testing.T) {
+	_, err := ParseCargoLockFile("nonexistent/Cargo.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseCargoLockFile_Malformed(t *testing.T) {
+	path := writeTempLock(t, "Cargo.lock", "[[package]\nname = ")
+	_, err := ParseCargoLockFile(path)
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
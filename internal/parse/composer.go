@@ -0,0 +1,122 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// ComposerPackage is an entry of the packages or packages-dev list of composer.lock.
+type ComposerPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"source"`
+	Dist struct {
+		Type   string `json:"type"`
+		URL    string `json:"url"`
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+	License []string `json:"license"`
+}
+
+type ComposerLockFile struct {
+	Packages         []ComposerPackage `json:"packages"`
+	PackagesDev      []ComposerPackage `json:"packages-dev"`
+	MinimumStability string            `json:"minimum-stability"`
+	// Composer writes an empty list instead of an object when there are no flags.
+	RawStabilityFlags json.RawMessage `json:"stability-flags"`
+}
+
+// Composer stabilities indexed by their stability-flags code divided by five
+// (stable = 0, RC = 5, beta = 10, alpha = 15, dev = 20).
+var composerStabilities = []string{"stable", "RC", "beta", "alpha", "dev"}
+
+// Stability returns the least stable release Composer accepts for a package:
+// the package's stability flag (e.g. "^2.0@beta") or else minimum-stability.
+func (l *ComposerLockFile) Stability(name string) string {
+	var flags map[string]int
+	if json.Unmarshal(l.RawStabilityFlags, &flags) == nil {
+		if code, ok := flags[strings.ToLower(name)]; ok && code%5 == 0 && code/5 < len(composerStabilities) {
+			return composerStabilities[code/5]
+		}
+	}
+	if l.MinimumStability == "" {
+		return "stable"
+	}
+	return l.MinimumStability
+}
+
+type composerManifest struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// ReadComposerLock decodes a composer.lock file.
+func ReadComposerLock(path string) (*ComposerLockFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read composer.lock: %w", err)
+	}
+	var lock ComposerLockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse composer.lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// ParseComposerLockFile parses a composer.lock file. Packages required by the
+// composer.json next to it are marked direct and carry its constraint as range.
+func ParseComposerLockFile(path string) ([]model.Dependency, error) {
+	lock, err := ReadComposerLock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest composerManifest
+	manifestPath := filepath.Join(filepath.Dir(path), "composer.json")
+	if fileExists(manifestPath) {
+		data, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read composer.json: %w", err)
+		}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse composer.json: %w", err)
+		}
+	}
+	// Package names are case-insensitive; composer.lock stores them in lower case.
+	required := make(map[string]string)
+	for _, reqs := range []map[string]string{manifest.RequireDev, manifest.Require} {
+		for name, constraint := range reqs {
+			required[strings.ToLower(name)] = constraint
+		}
+	}
+
+	deps := []model.Dependency{}
+	add := func(pkgs []ComposerPackage, scope model.Scope) {
+		for _, pkg := range pkgs {
+			rng, direct := required[strings.ToLower(pkg.Name)]
+			deps = append(deps, model.Dependency{
+				Ecosystem:    "composer",
+				Name:         pkg.Name,
+				ManifestPath: path,
+				Direct:       direct,
+				Scope:        scope,
+				Range:        rng,
+				Version:      pkg.Version,
+				Integrity:    pkg.Dist.Shasum,
+				Registry:     firstNonEmpty(pkg.Dist.URL, pkg.Source.URL),
+				License:      strings.Join(pkg.License, " OR "),
+			})
+		}
+	}
+	add(lock.Packages, model.ScopeProd)
+	add(lock.PackagesDev, model.ScopeDev)
+	return deps, nil
+}
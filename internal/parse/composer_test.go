@@ -0,0 +1,91 @@
+package parse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParseComposerLockFile_Valid(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"composer.json": `{
+  "require": {"php": "^8.2", "Monolog/Monolog": "^3.0", "symfony/console": "^7.0@beta"},
+  "require-dev": {"phpunit/phpunit": "^10.5"}
+}`,
+		"composer.lock": `{
+  "packages": [
+    {"name": "monolog/monolog", "version": "3.5.0",
+     "source": {"type": "git", "url": "https://github.com/Seldaek/monolog.git", "reference": "c915e2"},
+     "dist": {"type": "zip", "url": "https://api.github.com/repos/Seldaek/monolog/zipball/c915e2", "shasum": ""},
+     "license": ["MIT"]},
+    {"name": "psr/log", "version": "3.0.0", "license": ["MIT"]},
+    {"name": "symfony/console", "version": "v7.0.0-BETA1", "license": ["MIT"]}
+  ],
+  "packages-dev": [
+    {"name": "phpunit/phpunit", "version": "10.5.2", "license": ["BSD-3-Clause"]}
+  ],
+  "minimum-stability": "stable",
+  "stability-flags": {"symfony/console": 10}
+}`,
+	})
+	path := filepath.Join(dir, "composer.lock")
+	deps, err := ParseComposerLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 packages, got %d", len(deps))
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	monolog := byName["monolog/monolog"]
+	if !monolog.Direct || monolog.Range != "^3.0" || monolog.License != "MIT" || monolog.Registry != "https://api.github.com/repos/Seldaek/monolog/zipball/c915e2" {
+		t.Errorf("unexpected monolog: %+v", monolog)
+	}
+	if psr := byName["psr/log"]; psr.Direct {
+		t.Errorf("expected psr/log to be transitive, got %+v", psr)
+	}
+	if phpunit := byName["phpunit/phpunit"]; !phpunit.Direct || phpunit.Scope != model.ScopeDev {
+		t.Errorf("expected phpunit to be a direct dev dependency, got %+v", phpunit)
+	}
+
+	lock, err := ReadComposerLock(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := lock.Stability("symfony/console"); s != "beta" {
+		t.Errorf("expected beta for symfony/console, got %s", s)
+	}
+	if s := lock.Stability("psr/log"); s != "stable" {
+		t.Errorf("expected stable for psr/log, got %s", s)
+	}
+}
+
+func TestParseComposerLockFile_EmptyStabilityFlags(t *testing.T) {
+	path := writeTempLock(t, "composer.lock", `{"packages": [], "packages-dev": [], "minimum-stability": "dev", "stability-flags": []}`)
+	lock, err := ReadComposerLock(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := lock.Stability("any/package"); s != "dev" {
+		t.Errorf("expected dev, got %s", s)
+	}
+}
+
+func TestParseComposerLockFile_FileNotFound(t *testing.T) {
+	_, err := ParseComposerLockFile("nonexistent/composer.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseComposerLockFile_Malformed(t *testing.T) {
+	path := writeTempLock(t, "composer.lock", `{"packages": [`)
+	_, err := ParseComposerLockFile(path)
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
@@ -0,0 +1,176 @@
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// GemSpec is a locked gem listed under the specs of a GEM, GIT or PATH section.
+type GemSpec struct {
+	Name         string
+	Version      string
+	Platform     string            // e.g. "x86_64-linux" for native gems, empty for pure Ruby
+	Source       string            // "GEM", "GIT" or "PATH"
+	Remote       string            // gem server, git repository or local path
+	Revision     string            // git commit, GIT sources only
+	Dependencies map[string]string // runtime dependencies and their requirements
+}
+
+// GemfileLock is the content of a Gemfile.lock.
+type GemfileLock struct {
+	Specs        []GemSpec
+	Platforms    []string
+	Dependencies map[string]string // gems required by the Gemfile and their requirements
+	Pinned       map[string]bool   // Gemfile dependencies marked with "!" (GIT or PATH sources)
+	BundledWith  string
+}
+
+var (
+	gemSpecRe       = regexp.MustCompile(`^(\S+) \(([^)]+)\)$`)
+	gemDependencyRe = regexp.MustCompile(`^([^\s!(]+)(?: \(([^)]*)\))?(!)?$`)
+)
+
+// ParseGemfileLock parses a Gemfile.lock and returns the gems installed from gem
+// servers and git repositories. PATH gems are local and skipped. Gems listed in
+// DEPENDENCIES are direct; platform-specific variants of a version are merged.
+func ParseGemfileLock(path string) ([]model.Dependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Gemfile.lock: %w", err)
+	}
+	defer file.Close()
+
+	lock, err := ParseGemfileLockSections(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Gemfile.lock: %w", err)
+	}
+
+	deps := []model.Dependency{}
+	seen := make(map[string]bool)
+	for _, spec := range lock.Specs {
+		if spec.Source == "PATH" || seen[spec.Name+"@"+spec.Version] {
+			continue
+		}
+		seen[spec.Name+"@"+spec.Version] = true
+		registry := spec.Remote
+		if spec.Source == "GIT" {
+			registry = "git+" + spec.Remote + "#" + spec.Revision
+		}
+		rng, direct := lock.Dependencies[spec.Name]
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "bundler",
+			Name:         spec.Name,
+			ManifestPath: path,
+			Direct:       direct,
+			Scope:        model.ScopeProd,
+			Range:        rng,
+			Version:      spec.Version,
+			Registry:     registry,
+		})
+	}
+	return deps, nil
+}
+
+// ParseGemfileLockSections reads the sections of a Gemfile.lock. Unknown sections,
+// such as RUBY VERSION or CHECKSUMS, are ignored.
+func ParseGemfileLockSections(r io.Reader) (*GemfileLock, error) {
+	lock := &GemfileLock{
+		Dependencies: make(map[string]string),
+		Pinned:       make(map[string]bool),
+	}
+	var section, remote, revision string
+	var inSpecs, sawSection bool
+	var current *GemSpec
+	flush := func() {
+		if current != nil {
+			lock.Specs = append(lock.Specs, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+
+		if indent == 0 {
+			flush()
+			section, remote, revision, inSpecs = trimmed, "", "", false
+			switch section {
+			case "GEM", "GIT", "PATH", "DEPENDENCIES", "PLATFORMS":
+				sawSection = true
+			}
+			continue
+		}
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+			switch {
+			case indent == 2:
+				flush()
+				key, value, _ := strings.Cut(trimmed, ":")
+				switch key {
+				case "remote":
+					remote = strings.TrimSpace(value)
+				case "revision":
+					revision = strings.TrimSpace(value)
+				case "specs":
+					inSpecs = true
+				}
+			case inSpecs && indent == 4:
+				flush()
+				m := gemSpecRe.FindStringSubmatch(trimmed)
+				if m == nil {
+					return nil, fmt.Errorf("line %d: invalid spec %q", lineNo, trimmed)
+				}
+				version, platform, _ := strings.Cut(m[2], "-")
+				current = &GemSpec{
+					Name:         m[1],
+					Version:      version,
+					Platform:     platform,
+					Source:       section,
+					Remote:       remote,
+					Revision:     revision,
+					Dependencies: make(map[string]string),
+				}
+			case current != nil && indent == 6:
+				if m := gemDependencyRe.FindStringSubmatch(trimmed); m != nil {
+					current.Dependencies[m[1]] = m[2]
+				}
+			}
+		case "PLATFORMS":
+			lock.Platforms = append(lock.Platforms, trimmed)
+		case "DEPENDENCIES":
+			m := gemDependencyRe.FindStringSubmatch(trimmed)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: invalid dependency %q", lineNo, trimmed)
+			}
+			lock.Dependencies[m[1]] = m[2]
+			if m[3] != "" {
+				lock.Pinned[m[1]] = true
+			}
+		case "BUNDLED WITH":
+			lock.BundledWith = trimmed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	if !sawSection {
+		return nil, fmt.Errorf("no GEM, GIT, PATH or DEPENDENCIES section found")
+	}
+	return lock, nil
+}
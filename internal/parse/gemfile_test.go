@@ -0,0 +1,109 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+const gemfileLock = `GIT
+  remote: https://github.com/rails/rails.git
+  revision: 0123456789abcdef
+  branch: main
+  specs:
+    rails (7.2.0.alpha)
+      actionpack (= 7.2.0.alpha)
+
+PATH
+  remote: engines/billing
+  specs:
+    billing (0.1.0)
+      rails (>= 7.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    actionpack (7.2.0.alpha)
+    nokogiri (1.15.4-arm64-darwin)
+      racc (~> 1.4)
+    nokogiri (1.15.4-x86_64-linux)
+      racc (~> 1.4)
+    racc (1.7.3)
+    rake (13.0.6)
+
+PLATFORMS
+  arm64-darwin
+  x86_64-linux
+
+DEPENDENCIES
+  billing!
+  nokogiri (~> 1.15)
+  rails!
+  rake (>= 12.0, < 14)
+
+BUNDLED WITH
+   2.4.22
+`
+
+func TestParseGemfileLockSections(t *testing.T) {
+	lock, err := ParseGemfileLockSections(strings.NewReader(gemfileLock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lock.Specs) != 7 {
+		t.Fatalf("expected 7 specs, got %d: %+v", len(lock.Specs), lock.Specs)
+	}
+	if len(lock.Platforms) != 2 || lock.Platforms[1] != "x86_64-linux" {
+		t.Errorf("unexpected platforms: %v", lock.Platforms)
+	}
+	if lock.Dependencies["rake"] != ">= 12.0, < 14" || !lock.Pinned["rails"] || lock.Pinned["rake"] {
+		t.Errorf("unexpected dependencies: %v pinned %v", lock.Dependencies, lock.Pinned)
+	}
+	if lock.BundledWith != "2.4.22" {
+		t.Errorf("expected bundler 2.4.22, got %q", lock.BundledWith)
+	}
+	nokogiri := lock.Specs[3]
+	if nokogiri.Version != "1.15.4" || nokogiri.Platform != "arm64-darwin" || nokogiri.Dependencies["racc"] != "~> 1.4" {
+		t.Errorf("unexpected nokogiri spec: %+v", nokogiri)
+	}
+}
+
+func TestParseGemfileLock_Valid(t *testing.T) {
+	path := writeTempLock(t, "Gemfile.lock", gemfileLock)
+	deps, err := ParseGemfileLock(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 5 {
+		t.Fatalf("expected 5 gems (PATH skipped, platforms merged), got %d: %+v", len(deps), deps)
+	}
+	if rails := byName["rails"]; !rails.Direct || rails.Registry != "git+https://github.com/rails/rails.git#0123456789abcdef" {
+		t.Errorf("unexpected rails: %+v", rails)
+	}
+	if rake := byName["rake"]; !rake.Direct || rake.Version != "13.0.6" || rake.Range != ">= 12.0, < 14" || rake.Registry != "https://rubygems.org/" {
+		t.Errorf("unexpected rake: %+v", rake)
+	}
+	if racc := byName["racc"]; racc.Direct {
+		t.Errorf("expected racc to be transitive, got %+v", racc)
+	}
+}
+
+func TestParseGemfileLock_FileNotFound(t *testing.T) {
+	_, err := ParseGemfileLock("nonexistent/Gemfile.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseGemfileLock_Malformed(t *testing.T) {
+	path := writeTempLock(t, "Gemfile.lock", "GEM\n  remote: https://rubygems.org/\n  specs:\n    rake 13.0.6\n")
+	_, err := ParseGemfileLock(path)
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
@@ -3,23 +3,225 @@ package parse
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
+	"github.com/cyber-kamil/depflow/internal/model"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 )
 
-// ParseGoModFile parses a go.mod file and returns a map of module names to their versions.
-func ParseGoModFile(path string) (map[string]string, error) {
+// GoReplace is a replace directive. Old.Version is empty when it replaces every
+// version of the module; New.Version is empty when New.Path is a local directory.
+type GoReplace struct {
+	Old, New module.Version
+}
+
+// Target formats the replacement as written in go.mod: "path version" for a
+// module, or the directory for a filesystem replacement.
+func (r GoReplace) Target() string {
+	if r.New.Version == "" {
+		return r.New.Path
+	}
+	return r.New.Path + " " + r.New.Version
+}
+
+// GoRetract is a retract directive covering the versions Low through High.
+type GoRetract struct {
+	Low, High string
+	Rationale string
+}
+
+// GoModFile holds the directives of a go.mod file that affect dependency checks.
+type GoModFile struct {
+	Path    string // path of the go.mod file
+	Module  string
+	Require []*modfile.Require
+	Replace []GoReplace
+	Exclude []module.Version
+	Retract []GoRetract
+}
+
+// ReadGoModFile parses the go.mod file at path.
+func ReadGoModFile(path string) (*GoModFile, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read go.mod: %w", err)
 	}
-	mf, err := modfile.Parse("go.mod", data, nil)
+	return ParseGoModData(path, data)
+}
+
+// ParseGoModData parses the content of a go.mod file; path is used in errors.
+func ParseGoModData(path string, data []byte) (*GoModFile, error) {
+	mf, err := modfile.Parse(path, data, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
 	}
-	mods := make(map[string]string)
-	for _, req := range mf.Require {
-		mods[req.Mod.Path] = req.Mod.Version
+	f := &GoModFile{Path: path, Require: mf.Require}
+	if mf.Module != nil {
+		f.Module = mf.Module.Mod.Path
+	}
+	for _, r := range mf.Replace {
+		f.Replace = append(f.Replace, GoReplace{Old: r.Old, New: r.New})
+	}
+	for _, e := range mf.Exclude {
+		f.Exclude = append(f.Exclude, e.Mod)
+	}
+	for _, r := range mf.Retract {
+		f.Retract = append(f.Retract, GoRetract{Low: r.Low, High: r.High, Rationale: r.Rationale})
+	}
+	return f, nil
+}
+
+// Replacement returns the replace directive that applies to path@version.
+// A directive naming the exact version wins over one for all versions.
+func (f *GoModFile) Replacement(path, version string) (GoReplace, bool) {
+	return findGoReplace(f.Replace, path, version)
+}
+
+func findGoReplace(replaces []GoReplace, path, version string) (GoReplace, bool) {
+	var found GoReplace
+	ok := false
+	for _, r := range replaces {
+		if r.Old.Path != path {
+			continue
+		}
+		if r.Old.Version == version {
+			return r, true
+		}
+		if r.Old.Version == "" {
+			found, ok = r, true
+		}
+	}
+	return found, ok
+}
+
+// Excluded reports whether an exclude directive names path@version.
+func (f *GoModFile) Excluded(path, version string) bool {
+	for _, e := range f.Exclude {
+		if e.Path == path && e.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Retracted reports whether a retract directive of this module covers version
+// and returns its rationale.
+func (f *GoModFile) Retracted(version string) (string, bool) {
+	for _, r := range f.Retract {
+		if semver.Compare(r.Low, version) <= 0 && semver.Compare(version, r.High) <= 0 {
+			return r.Rationale, true
+		}
+	}
+	return "", false
+}
+
+// ParseGoModFile parses a go.mod file and returns the modules it requires.
+// Replaced requirements carry the replacement in Replace; for a module
+// replacement Version is the replacement's version, as that is what is built.
+func ParseGoModFile(path string) ([]model.Dependency, error) {
+	f, err := ReadGoModFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return goRequirements(f, nil, nil), nil
+}
+
+// goRequirements returns the requirements of f, skipping modules in skip.
+// Replacements in override (from go.work) take precedence over those of f.
+func goRequirements(f *GoModFile, override []GoReplace, skip map[string]bool) []model.Dependency {
+	mods := []model.Dependency{}
+	for _, req := range f.Require {
+		if skip[req.Mod.Path] {
+			continue
+		}
+		dep := model.Dependency{
+			Ecosystem:    "go",
+			Name:         req.Mod.Path,
+			ManifestPath: f.Path,
+			Direct:       !req.Indirect,
+			Scope:        model.ScopeProd,
+			Version:      req.Mod.Version,
+		}
+		r, ok := findGoReplace(override, req.Mod.Path, req.Mod.Version)
+		if !ok {
+			r, ok = f.Replacement(req.Mod.Path, req.Mod.Version)
+		}
+		if ok {
+			dep.Replace = r.Target()
+			if r.New.Version != "" {
+				dep.Version = r.New.Version
+			}
+		}
+		mods = append(mods, dep)
+	}
+	return mods
+}
+
+// SplitGoReplace splits a Dependency.Replace value into the replacement module
+// path and version. The version is empty for a local directory.
+func SplitGoReplace(replace string) (path, version string) {
+	if i := strings.LastIndex(replace, " "); i > 0 && semver.IsValid(replace[i+1:]) {
+		return replace[:i], replace[i+1:]
+	}
+	return replace, ""
+}
+
+// GoWorkFile is a parsed go.work file.
+type GoWorkFile struct {
+	Modules []string // go.mod paths of the used modules, in use order
+	Replace []GoReplace
+}
+
+// ReadGoWorkFile parses the go.work file at path.
+func ReadGoWorkFile(path string) (*GoWorkFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	wf, err := modfile.ParseWork(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+	work := &GoWorkFile{}
+	for _, use := range wf.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(path), dir)
+		}
+		work.Modules = append(work.Modules, filepath.Join(dir, "go.mod"))
+	}
+	for _, r := range wf.Replace {
+		work.Replace = append(work.Replace, GoReplace{Old: r.Old, New: r.New})
+	}
+	return work, nil
+}
+
+// ParseGoWorkFile parses a go.work file and returns the requirements of every
+// module it uses, each with the requiring go.mod as ManifestPath. Requirements
+// on modules that are part of the workspace are skipped, and replace
+// directives of go.work override those of the modules.
+func ParseGoWorkFile(path string) ([]model.Dependency, error) {
+	work, err := ReadGoWorkFile(path)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]bool)
+	files := make([]*GoModFile, 0, len(work.Modules))
+	for _, modPath := range work.Modules {
+		f, err := ReadGoModFile(modPath)
+		if err != nil {
+			return nil, err
+		}
+		local[f.Module] = true
+		files = append(files, f)
+	}
+
+	deps := []model.Dependency{}
+	for _, f := range files {
+		deps = append(deps, goRequirements(f, work.Replace, local)...)
 	}
-	return mods, nil
+	return deps, nil
 }
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -25,15 +26,43 @@ require (
 	}
 	tmp.Close()
 
-	mods, err := ParseGoModFile(tmp.Name())
+	list, err := ParseGoModFile(tmp.Name())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	mods := versions(list)
 	if mods["github.com/stretchr/testify"] != "v1.8.0" || mods["golang.org/x/mod"] != "v0.12.0" {
 		t.Errorf("unexpected mods: %v", mods)
 	}
 }
 
+func TestParseGoModFile_Indirect(t *testing.T) {
+	gomod := `module github.com/example/project
+
+go 1.20
+
+require github.com/stretchr/testify v1.8.0
+
+require github.com/davecgh/go-spew v1.1.1 // indirect
+`
+	path := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(path, []byte(gomod), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	mods, err := ParseGoModFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, m := range mods {
+		if m.Ecosystem != "go" || m.ManifestPath != path {
+			t.Errorf("unexpected metadata: %+v", m)
+		}
+		if m.Direct != (m.Name == "github.com/stretchr/testify") {
+			t.Errorf("unexpected direct flag for %s: %v", m.Name, m.Direct)
+		}
+	}
+}
+
 func TestParseGoModFile_MissingFile(t *testing.T) {
 	_, err := ParseGoModFile("nonexistent.mod")
 	if err == nil {
@@ -59,3 +88,116 @@ require github.com/stretchr/testify` // missing version
 		t.Error("expected error for malformed file, got nil")
 	}
 }
+
+func TestParseGoWorkFile(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"go.work": "go 1.22\n\nuse (\n\t./api\n\t./lib\n)\n",
+		"api/go.mod": `module example.com/api
+
+go 1.22
+
+require (
+	example.com/lib v0.0.0
+	github.com/stretchr/testify v1.8.0
+	golang.org/x/mod v0.12.0 // indirect
+)
+`,
+		"lib/go.mod": `module example.com/lib
+
+go 1.22
+
+require github.com/stretchr/testify v1.9.0
+`,
+	})
+	deps, err := ParseGoWorkFile(filepath.Join(dir, "go.work"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 3 {
+		t.Fatalf("expected 3 requirements without workspace modules, got %d: %+v", len(deps), deps)
+	}
+	if deps[0].ManifestPath != filepath.Join(dir, "api", "go.mod") || deps[2].ManifestPath != filepath.Join(dir, "lib", "go.mod") {
+		t.Errorf("unexpected manifest paths: %+v", deps)
+	}
+	if deps[2].Name != "github.com/stretchr/testify" || deps[2].Version != "v1.9.0" {
+		t.Errorf("unexpected lib requirement: %+v", deps[2])
+	}
+}
+
+func TestParseGoWorkFile_MissingModule(t *testing.T) {
+	dir := writeProject(t, map[string]string{"go.work": "go 1.22\n\nuse ./missing\n"})
+	if _, err := ParseGoWorkFile(filepath.Join(dir, "go.work")); err == nil {
+		t.Error("expected error for missing module, got nil")
+	}
+}
+
+func TestParseGoModFile_Directives(t *testing.T) {
+	dir := writeProject(t, map[string]string{"go.mod": `module github.com/example/project
+
+go 1.22
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/sirupsen/logrus v1.9.0
+	golang.org/x/text v0.14.0
+)
+
+replace github.com/pkg/errors => github.com/fork/errors v0.9.2
+
+replace github.com/sirupsen/logrus v1.9.0 => ../logrus
+
+replace github.com/sirupsen/logrus v1.8.0 => github.com/fork/logrus v1.8.1
+
+exclude golang.org/x/text v0.15.0
+
+retract [v1.0.0, v1.0.5] // published with broken API
+`})
+	path := filepath.Join(dir, "go.mod")
+	deps, err := ParseGoModFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deps[0].Replace != "github.com/fork/errors v0.9.2" || deps[0].Version != "v0.9.2" {
+		t.Errorf("unexpected module replacement: %+v", deps[0])
+	}
+	if deps[1].Replace != "../logrus" || deps[1].Version != "v1.9.0" {
+		t.Errorf("expected version-specific filesystem replacement, got %+v", deps[1])
+	}
+	if deps[2].Replace != "" {
+		t.Errorf("expected golang.org/x/text not to be replaced, got %+v", deps[2])
+	}
+	if p, v := SplitGoReplace(deps[0].Replace); p != "github.com/fork/errors" || v != "v0.9.2" {
+		t.Errorf("unexpected split: %q %q", p, v)
+	}
+	if p, v := SplitGoReplace(deps[1].Replace); p != "../logrus" || v != "" {
+		t.Errorf("unexpected split: %q %q", p, v)
+	}
+
+	f, err := ReadGoModFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Excluded("golang.org/x/text", "v0.15.0") || f.Excluded("golang.org/x/text", "v0.14.0") {
+		t.Errorf("unexpected exclusions: %+v", f.Exclude)
+	}
+	if rationale, ok := f.Retracted("v1.0.3"); !ok || rationale != "published with broken API" {
+		t.Errorf("expected v1.0.3 to be retracted, got %q %v", rationale, ok)
+	}
+	if _, ok := f.Retracted("v1.1.0"); ok {
+		t.Error("expected v1.1.0 not to be retracted")
+	}
+}
+
+func TestParseGoWorkFile_Replace(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"go.work":    "go 1.22\n\nuse ./app\n\nreplace github.com/pkg/errors => ./errors\n",
+		"app/go.mod": "module example.com/app\n\ngo 1.22\n\nrequire github.com/pkg/errors v0.9.1\n\nreplace github.com/pkg/errors => github.com/fork/errors v0.9.2\n",
+	})
+	deps, err := ParseGoWorkFile(filepath.Join(dir, "go.work"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Replace != "./errors" || deps[0].Version != "v0.9.1" {
+		t.Errorf("expected go.work replacement to win, got %+v", deps)
+	}
+}
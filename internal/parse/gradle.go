@@ -0,0 +1,367 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// GradleLockFiles are the dependency lock files Gradle writes next to the build script.
+var GradleLockFiles = []string{"gradle.lockfile", "buildscript-gradle.lockfile"}
+
+// GradleBuildFiles are the build scripts read for declared dependencies.
+var GradleBuildFiles = []string{"build.gradle", "build.gradle.kts"}
+
+// GradleVersionCatalog is the default version catalog path relative to the project.
+var GradleVersionCatalog = filepath.Join("gradle", "libs.versions.toml")
+
+var (
+	gradleConfigRe   = regexp.MustCompile(`(?i)^(\w*implementation|\w*api|\w*compileOnly|\w*runtimeOnly|\w*annotationProcessor|kapt\w*|ksp\w*|classpath|compile|runtime|testCompile|testRuntime)$`)
+	gradleStringRe   = regexp.MustCompile(`\b(\w+)\s*\(?\s*(?:(?:enforcedPlatform|platform)\s*\(\s*)?["']([^"':\s]+):([^"':\s]+):([^"'\s:@]+)(?::[^"'\s@]+)?(?:@\w+)?["']`)
+	gradleMapRe      = regexp.MustCompile(`\b(\w+)\s*\(?\s*group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["']\s*,\s*version\s*[:=]\s*["']([^"']+)["']`)
+	gradleCatalogRe  = regexp.MustCompile(`\b(\w+)\s*\(?\s*(?:(?:enforcedPlatform|platform)\s*\(\s*)?libs\.([\w.]+)`)
+	gradleAssignRe   = regexp.MustCompile(`(?:^|[\s.])(\w+)\s*(?::\s*String\s*)?=\s*["']([^"'$]+)["']`)
+	gradleSetRe      = regexp.MustCompile(`(?:set|extra\.set)\(\s*["'](\w+)["']\s*,\s*["']([^"'$]+)["']\s*\)|extra\[\s*["'](\w+)["']\s*\]\s*=\s*["']([^"'$]+)["']`)
+	gradleVarRefRe   = regexp.MustCompile(`\$\{?([\w.]+?)\}?$`)
+	gradleAliasSepRe = regexp.MustCompile(`[-_.]`)
+)
+
+// ParseGradleProject returns the dependencies of the Gradle project containing path,
+// which may be a lock file, a build script or gradle/libs.versions.toml.
+// When gradle.lockfile or buildscript-gradle.lockfile exist their exact coordinates are
+// returned and the build scripts and version catalog only mark direct dependencies.
+// Otherwise the statically declared dependencies are returned.
+func ParseGradleProject(path string) ([]model.Dependency, error) {
+	dir := filepath.Dir(path)
+	if filepath.Base(path) == filepath.Base(GradleVersionCatalog) && filepath.Base(dir) == "gradle" {
+		dir = filepath.Dir(dir)
+	}
+
+	declared := []model.Dependency{}
+	catalogPath := filepath.Join(dir, GradleVersionCatalog)
+	catalog := map[string]model.Dependency{}
+	if fileExists(catalogPath) {
+		var err error
+		if catalog, err = ParseGradleVersionCatalog(catalogPath); err != nil {
+			return nil, err
+		}
+	}
+	referenced := map[string]bool{}
+	for _, name := range GradleBuildFiles {
+		buildPath := filepath.Join(dir, name)
+		if !fileExists(buildPath) {
+			continue
+		}
+		deps, err := ParseGradleBuildFile(buildPath, catalog)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deps {
+			referenced[d.Name] = true
+		}
+		declared = append(declared, deps...)
+	}
+	// Catalog entries that no build script in this directory uses may still be
+	// used by subprojects, so they count as declared too.
+	for _, alias := range sortedKeys(catalog) {
+		if d := catalog[alias]; !referenced[d.Name] {
+			declared = append(declared, d)
+			referenced[d.Name] = true
+		}
+	}
+
+	locked := []model.Dependency{}
+	for _, name := range GradleLockFiles {
+		lockPath := filepath.Join(dir, name)
+		if !fileExists(lockPath) {
+			continue
+		}
+		deps, err := ParseGradleLockFile(lockPath)
+		if err != nil {
+			return nil, err
+		}
+		locked = append(locked, deps...)
+	}
+	if len(locked) == 0 {
+		return declared, nil
+	}
+
+	byName := make(map[string]model.Dependency)
+	for _, d := range declared {
+		if _, ok := byName[d.Name]; !ok {
+			byName[d.Name] = d
+		}
+	}
+	for i := range locked {
+		if d, ok := byName[locked[i].Name]; ok {
+			locked[i].Direct = true
+			locked[i].Range = d.Range
+		}
+	}
+	return locked, nil
+}
+
+// ParseGradleLockFile parses a gradle.lockfile and returns the locked modules with
+// the configurations resolving them as Groups.
+func ParseGradleLockFile(path string) ([]model.Dependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	deps := []model.Dependency{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		coords, configs, ok := strings.Cut(line, "=")
+		if !ok || coords == "empty" {
+			continue
+		}
+		parts := strings.Split(coords, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("failed to parse %s: invalid line %q", filepath.Base(path), line)
+		}
+		groups := strings.Split(configs, ",")
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "gradle",
+			Name:         parts[0] + ":" + parts[1],
+			ManifestPath: path,
+			Scope:        gradleScope(groups...),
+			Groups:       groups,
+			Version:      parts[2],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", filepath.Base(path), err)
+	}
+	return deps, nil
+}
+
+type gradleCatalogFile struct {
+	Versions  map[string]interface{} `toml:"versions"`
+	Libraries map[string]interface{} `toml:"libraries"`
+}
+
+// ParseGradleVersionCatalog parses a libs.versions.toml version catalog and returns
+// its libraries keyed by alias.
+func ParseGradleVersionCatalog(path string) (map[string]model.Dependency, error) {
+	var catalog gradleCatalogFile
+	if _, err := toml.DecodeFile(path, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+
+	libs := make(map[string]model.Dependency)
+	for alias, value := range catalog.Libraries {
+		var module, rng string
+		switch v := value.(type) {
+		case string:
+			parts := strings.Split(v, ":")
+			if len(parts) < 2 {
+				continue
+			}
+			module = parts[0] + ":" + parts[1]
+			if len(parts) > 2 {
+				rng = parts[2]
+			}
+		case map[string]interface{}:
+			if m, ok := v["module"].(string); ok {
+				module = m
+			} else {
+				group, _ := v["group"].(string)
+				name, _ := v["name"].(string)
+				module = group + ":" + name
+			}
+			rng = gradleCatalogVersion(v["version"], catalog.Versions)
+		}
+		if module == "" || strings.HasPrefix(module, ":") || strings.HasSuffix(module, ":") {
+			continue
+		}
+		dep := model.Dependency{
+			Ecosystem:    "gradle",
+			Name:         module,
+			ManifestPath: path,
+			Direct:       true,
+			Scope:        model.ScopeProd,
+			Range:        rng,
+		}
+		if isGradleExactVersion(rng) {
+			dep.Version = rng
+		}
+		libs[alias] = dep
+	}
+	return libs, nil
+}
+
+// gradleCatalogVersion resolves a catalog version, which is a string, a
+// {ref = "..."} table or a rich version such as {strictly = "...", prefer = "..."}.
+func gradleCatalogVersion(value interface{}, versions map[string]interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if ref, ok := v["ref"].(string); ok {
+			return gradleCatalogVersion(versions[ref], nil)
+		}
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// ParseGradleBuildFile extracts dependencies declared with literal coordinates,
+// such as implementation "g:a:v" or testImplementation(group: "g", name: "a", version: "v"),
+// from a build.gradle or build.gradle.kts file. Versions may reference variables assigned
+// in the same file, and libs.<alias> accessors are resolved through catalog.
+// This is a static, best-effort extraction: nothing is evaluated.
+func ParseGradleBuildFile(path string, catalog map[string]model.Dependency) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	text := string(stripGradleComments(data))
+
+	vars := make(map[string]string)
+	for _, m := range gradleAssignRe.FindAllStringSubmatch(text, -1) {
+		vars[m[1]] = m[2]
+	}
+	for _, m := range gradleSetRe.FindAllStringSubmatch(text, -1) {
+		if m[1] != "" {
+			vars[m[1]] = m[2]
+		} else {
+			vars[m[3]] = m[4]
+		}
+	}
+
+	deps := []model.Dependency{}
+	add := func(config, group, artifact, rng string) {
+		if !gradleConfigRe.MatchString(config) {
+			return
+		}
+		if m := gradleVarRefRe.FindStringSubmatch(rng); m != nil {
+			name := m[1]
+			if idx := strings.LastIndex(name, "."); idx >= 0 {
+				name = name[idx+1:]
+			}
+			rng = vars[name]
+		}
+		dep := model.Dependency{
+			Ecosystem:    "gradle",
+			Name:         group + ":" + artifact,
+			ManifestPath: path,
+			Direct:       true,
+			Scope:        gradleScope(config),
+			Groups:       []string{config},
+			Range:        rng,
+		}
+		if isGradleExactVersion(rng) {
+			dep.Version = rng
+		}
+		deps = append(deps, dep)
+	}
+	for _, m := range gradleStringRe.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2], m[3], m[4])
+	}
+	for _, m := range gradleMapRe.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[2], m[3], m[4])
+	}
+
+	aliases := make(map[string]string)
+	for alias := range catalog {
+		aliases[gradleAliasSepRe.ReplaceAllString(alias, ".")] = alias
+	}
+	for _, m := range gradleCatalogRe.FindAllStringSubmatch(text, -1) {
+		lib, ok := catalog[aliases[m[2]]]
+		if !ok || !gradleConfigRe.MatchString(m[1]) {
+			continue
+		}
+		lib.Scope = gradleScope(m[1])
+		lib.Groups = []string{m[1]}
+		deps = append(deps, lib)
+	}
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+// stripGradleComments removes // and /* */ comments from a Groovy or Kotlin
+// build script, leaving single-, double- and triple-quoted string literals intact.
+func stripGradleComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	quote := ""
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case quote != "":
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, c, data[i+1])
+				i++
+				continue
+			}
+			if bytes.HasPrefix(data[i:], []byte(quote)) {
+				out = append(out, quote...)
+				i += len(quote) - 1
+				quote = ""
+				continue
+			}
+			out = append(out, c)
+		case c == '\'' || c == '"':
+			quote = string(c)
+			if triple := strings.Repeat(quote, 3); bytes.HasPrefix(data[i:], []byte(triple)) {
+				quote = triple
+			}
+			out = append(out, quote...)
+			i += len(quote) - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// gradleScope returns dev if every configuration is a test configuration.
+func gradleScope(configs ...string) model.Scope {
+	for _, c := range configs {
+		if !strings.HasPrefix(c, "test") && !strings.HasPrefix(c, "androidTest") {
+			return model.ScopeProd
+		}
+	}
+	if len(configs) == 0 {
+		return model.ScopeProd
+	}
+	return model.ScopeDev
+}
+
+// isGradleExactVersion reports whether v pins a single version rather than a
+// dynamic version (1.+, latest.release) or a range ([1.0,2.0)).
+func isGradleExactVersion(v string) bool {
+	return v != "" && !strings.ContainsAny(v, "+[]()$,") && !strings.HasPrefix(v, "latest.")
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
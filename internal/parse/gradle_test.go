@@ -0,0 +1,173 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+const gradleCatalog = `[versions]
+okhttp = "4.12.0"
+
+[libraries]
+okhttp = { module = "com.squareup.okhttp3:okhttp", version.ref = "okhttp" }
+junit-jupiter = { group = "org.junit.jupiter", name = "junit-jupiter", version = { strictly = "5.10.1" } }
+commons-lang = "org.apache.commons:commons-lang3:3.14.0"
+`
+
+const gradleBuild = `plugins {
+    id 'java'
+}
+
+ext {
+    guavaVersion = '32.1.3-jre'
+}
+
+dependencies {
+    implementation "com.google.guava:guava:$guavaVersion"
+    implementation platform('org.springframework.boot:spring-boot-dependencies:3.2.0')
+    implementation libs.okhttp
+    testImplementation libs.junit.jupiter
+    testImplementation group: 'org.mockito', name: 'mockito-core', version: '5.8.0'
+    runtimeOnly 'org.postgresql:postgresql:42.+'
+    // implementation 'commented:out:1.0'
+}
+`
+
+func TestParseGradleBuildFile_Valid(t *testing.T) {
+	dir := writeProject(t, map[string]string{"build.gradle": gradleBuild})
+	if err := os.Mkdir(filepath.Join(dir, "gradle"), 0755); err != nil {
+		t.Fatalf("failed to create gradle dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, GradleVersionCatalog), []byte(gradleCatalog), 0644); err != nil {
+		t.Fatalf("failed to write catalog: %v", err)
+	}
+
+	deps, err := ParseGradleProject(filepath.Join(dir, "build.gradle"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	want := map[string]string{
+		"com.google.guava:guava":                            "32.1.3-jre",
+		"org.springframework.boot:spring-boot-dependencies": "3.2.0",
+		"com.squareup.okhttp3:okhttp":                       "4.12.0",
+		"org.junit.jupiter:junit-jupiter":                   "5.10.1",
+		"org.mockito:mockito-core":                          "5.8.0",
+		"org.postgresql:postgresql":                         "",
+		"org.apache.commons:commons-lang3":                  "3.14.0",
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("expected %d dependencies, got %+v", len(want), deps)
+	}
+	for name, version := range want {
+		if d, ok := byName[name]; !ok || d.Version != version || !d.Direct {
+			t.Errorf("unexpected %s: %+v", name, d)
+		}
+	}
+	if d := byName["org.junit.jupiter:junit-jupiter"]; d.Scope != model.ScopeDev || d.Groups[0] != "testImplementation" {
+		t.Errorf("unexpected junit-jupiter: %+v", d)
+	}
+	if d := byName["org.postgresql:postgresql"]; d.Range != "42.+" {
+		t.Errorf("unexpected postgresql: %+v", d)
+	}
+}
+
+func TestParseGradleBuildFile_QuotedCommentMarkers(t *testing.T) {
+	build := `repositories {
+    maven { url 'https://repo.example.com/releases' } // company mirror
+    maven { url = uri("https://repo.example.com/snapshots") }
+}
+dependencies {
+    implementation fileTree(dir: 'libs', include: ['**/*.jar'])
+    /* implementation 'com.example:commented:1.0' */
+    implementation 'com.google.guava:guava:32.1.3-jre' // pinned
+    testImplementation("org.junit.jupiter:junit-jupiter:5.10.1")
+    def banner = '''see http://example.com /* not a comment '''
+    runtimeOnly "org.postgresql:postgresql:42.7.1"
+}
+`
+	dir := writeProject(t, map[string]string{"build.gradle": build})
+	deps, err := ParseGradleBuildFile(filepath.Join(dir, "build.gradle"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := versions(deps)
+	want := map[string]string{
+		"com.google.guava:guava":          "32.1.3-jre",
+		"org.junit.jupiter:junit-jupiter": "5.10.1",
+		"org.postgresql:postgresql":       "42.7.1",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for name, version := range want {
+		if got[name] != version {
+			t.Errorf("expected %s %s, got %v", name, version, got)
+		}
+	}
+}
+
+func TestParseGradleProject_LockFile(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"build.gradle.kts": `dependencies {
+    implementation("com.google.guava:guava:32.+")
+    testImplementation("junit:junit:4.13.2")
+}
+`,
+		"gradle.lockfile": `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.google.guava:failureaccess:1.0.1=compileClasspath,runtimeClasspath
+com.google.guava:guava:32.1.3-jre=compileClasspath,runtimeClasspath
+junit:junit:4.13.2=testCompileClasspath,testRuntimeClasspath
+empty=annotationProcessor
+`,
+		"buildscript-gradle.lockfile": "org.jetbrains.kotlin:kotlin-gradle-plugin:1.9.21=classpath\nempty=\n",
+	})
+	deps, err := ParseGradleProject(filepath.Join(dir, "gradle.lockfile"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := []string{}
+	for _, d := range deps {
+		got = append(got, d.Name+":"+d.Version)
+		switch d.Name {
+		case "com.google.guava:guava":
+			if !d.Direct || d.Range != "32.+" || d.Scope != model.ScopeProd || strings.Join(d.Groups, ",") != "compileClasspath,runtimeClasspath" {
+				t.Errorf("unexpected guava: %+v", d)
+			}
+		case "junit:junit":
+			if !d.Direct || d.Scope != model.ScopeDev {
+				t.Errorf("unexpected junit: %+v", d)
+			}
+		case "com.google.guava:failureaccess":
+			if d.Direct {
+				t.Errorf("unexpected failureaccess: %+v", d)
+			}
+		}
+	}
+	if len(deps) != 4 {
+		t.Errorf("expected 4 locked dependencies, got %v", got)
+	}
+}
+
+func TestParseGradleLockFile_Malformed(t *testing.T) {
+	_, err := ParseGradleLockFile(writeTempLock(t, "gradle.lockfile", "com.google.guava:guava=compileClasspath\n"))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
+
+func TestParseGradleLockFile_MissingFile(t *testing.T) {
+	_, err := ParseGradleLockFile("nonexistent.lockfile")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
@@ -0,0 +1,263 @@
+package parse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// MavenLocalRepository is the local Maven repository searched for parent POMs
+// that are not in the source tree and for imported BOMs.
+var MavenLocalRepository = defaultMavenLocalRepository()
+
+func defaultMavenLocalRepository() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".m2", "repository")
+}
+
+// PomDependency is a <dependency> element of a POM.
+type PomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Scope      string `xml:"scope"`
+	Optional   string `xml:"optional"`
+}
+
+// PomProject is the subset of a Maven POM depflow reads.
+type PomProject struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID      string  `xml:"groupId"`
+		ArtifactID   string  `xml:"artifactId"`
+		Version      string  `xml:"version"`
+		RelativePath *string `xml:"relativePath"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	DependencyManagement struct {
+		Dependencies []PomDependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+	Dependencies []PomDependency `xml:"dependencies>dependency"`
+	SCM          struct {
+		URL string `xml:"url"`
+	} `xml:"scm"`
+	URL string `xml:"url"`
+}
+
+// effectivePom is a POM merged with its parents.
+type effectivePom struct {
+	props      map[string]string
+	managed    map[string]PomDependency // keyed by groupId:artifactId
+	deps       []PomDependency
+	groupID    string
+	artifactID string
+	version    string
+}
+
+var pomPropertyRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// ParsePomFile parses a Maven pom.xml and returns its dependencies with versions
+// resolved through properties, dependencyManagement, parent POMs and imported BOMs.
+// Dependency scopes are kept in Groups; test dependencies are scoped dev.
+func ParsePomFile(path string) ([]model.Dependency, error) {
+	eff, err := loadEffectivePom(path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := []model.Dependency{}
+	for _, d := range eff.deps {
+		groupID := eff.interpolate(d.GroupID)
+		artifactID := eff.interpolate(d.ArtifactID)
+		key := groupID + ":" + artifactID
+		managed := eff.managed[key]
+		rng := eff.interpolate(d.Version)
+		if rng == "" {
+			rng = eff.interpolate(managed.Version)
+		}
+		scope := eff.interpolate(d.Scope)
+		if scope == "" {
+			scope = eff.interpolate(managed.Scope)
+		}
+		if scope == "" {
+			scope = "compile"
+		}
+		dep := model.Dependency{
+			Ecosystem:    "maven",
+			Name:         key,
+			ManifestPath: path,
+			Direct:       true,
+			Scope:        model.ScopeProd,
+			Optional:     eff.interpolate(d.Optional) == "true",
+			Groups:       []string{scope},
+			Range:        rng,
+		}
+		if scope == "test" {
+			dep.Scope = model.ScopeDev
+		}
+		if !IsMavenVersionRange(rng) {
+			dep.Version = rng
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// IsMavenVersionRange reports whether v is a range such as "[1.0,2.0)".
+func IsMavenVersionRange(v string) bool {
+	return strings.HasPrefix(v, "[") || strings.HasPrefix(v, "(")
+}
+
+func loadEffectivePom(path string, depth int) (*effectivePom, error) {
+	if depth > 10 {
+		return nil, fmt.Errorf("failed to parse %s: parent chain too deep", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pom.xml: %w", err)
+	}
+	var pom PomProject
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	eff := &effectivePom{props: make(map[string]string), managed: make(map[string]PomDependency)}
+	if pom.Parent.ArtifactID != "" {
+		if parentPath := findParentPom(path, pom); parentPath != "" {
+			parent, err := loadEffectivePom(parentPath, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			eff = parent
+			eff.props["project.parent.groupId"] = parent.groupID
+			eff.props["project.parent.artifactId"] = parent.artifactID
+			eff.props["project.parent.version"] = parent.version
+		}
+	}
+
+	eff.groupID = firstNonEmpty(pom.GroupID, pom.Parent.GroupID)
+	eff.artifactID = pom.ArtifactID
+	eff.version = firstNonEmpty(pom.Version, pom.Parent.Version)
+	for _, prefix := range []string{"project.", "pom."} {
+		eff.props[prefix+"groupId"] = eff.groupID
+		eff.props[prefix+"artifactId"] = eff.artifactID
+		eff.props[prefix+"version"] = eff.version
+	}
+	for _, p := range pom.Properties.Entries {
+		eff.props[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+
+	// Managed versions and scopes are interpolated when they are used, so
+	// properties overridden by a child POM apply to its parent's declarations.
+	for _, d := range pom.DependencyManagement.Dependencies {
+		d.GroupID = eff.interpolate(d.GroupID)
+		d.ArtifactID = eff.interpolate(d.ArtifactID)
+		if eff.interpolate(d.Scope) == "import" && eff.interpolate(d.Type) == "pom" {
+			eff.importBom(d.GroupID, d.ArtifactID, eff.interpolate(d.Version), depth)
+			continue
+		}
+		eff.managed[d.GroupID+":"+d.ArtifactID] = d
+	}
+	eff.deps = append(eff.deps, pom.Dependencies...)
+	return eff, nil
+}
+
+// importBom merges the dependencyManagement of a BOM found in MavenLocalRepository.
+// BOMs that are not available locally are ignored.
+func (eff *effectivePom) importBom(groupID, artifactID, version string, depth int) {
+	bomPath := mavenLocalPom(groupID, artifactID, version)
+	if bomPath == "" {
+		return
+	}
+	bom, err := loadEffectivePom(bomPath, depth+1)
+	if err != nil {
+		return
+	}
+	for key, managed := range bom.managed {
+		managed.Version = bom.interpolate(managed.Version)
+		managed.Scope = bom.interpolate(managed.Scope)
+		if _, ok := eff.managed[key]; !ok {
+			eff.managed[key] = managed
+		}
+	}
+}
+
+// interpolate replaces ${property} placeholders, following nested properties.
+// Unknown properties are left in place.
+func (eff *effectivePom) interpolate(s string) string {
+	s = strings.TrimSpace(s)
+	for i := 0; i < 10 && strings.Contains(s, "${"); i++ {
+		next := pomPropertyRe.ReplaceAllStringFunc(s, func(m string) string {
+			if v, ok := eff.props[m[2:len(m)-1]]; ok {
+				return v
+			}
+			return m
+		})
+		if next == s {
+			break
+		}
+		s = next
+	}
+	return s
+}
+
+// findParentPom locates the parent POM through relativePath (default ../pom.xml),
+// falling back to MavenLocalRepository.
+func findParentPom(path string, pom PomProject) string {
+	rel := "../pom.xml"
+	if pom.Parent.RelativePath != nil {
+		rel = strings.TrimSpace(*pom.Parent.RelativePath)
+	}
+	if rel != "" {
+		candidate := filepath.Join(filepath.Dir(path), rel)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			candidate = filepath.Join(candidate, "pom.xml")
+		}
+		if data, err := os.ReadFile(candidate); err == nil {
+			var parent PomProject
+			if xml.Unmarshal(data, &parent) == nil && parent.ArtifactID == pom.Parent.ArtifactID &&
+				firstNonEmpty(parent.GroupID, parent.Parent.GroupID) == pom.Parent.GroupID {
+				return candidate
+			}
+		}
+	}
+	return mavenLocalPom(pom.Parent.GroupID, pom.Parent.ArtifactID, pom.Parent.Version)
+}
+
+// mavenLocalPom returns the path of a POM in MavenLocalRepository, or "".
+func mavenLocalPom(groupID, artifactID, version string) string {
+	if MavenLocalRepository == "" || groupID == "" || artifactID == "" || version == "" {
+		return ""
+	}
+	path := filepath.Join(MavenLocalRepository, filepath.FromSlash(strings.ReplaceAll(groupID, ".", "/")),
+		artifactID, version, artifactID+"-"+version+".pom")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParsePomFile_Valid(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"pom.xml": `<project>
+  <groupId>com.example</groupId>
+  <artifactId>parent</artifactId>
+  <version>1.0.0</version>
+  <packaging>pom</packaging>
+  <properties>
+    <jackson.version>2.15.0</jackson.version>
+    <junit.version>5.10.0</junit.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-databind</artifactId>
+        <version>${jackson.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>org.junit.jupiter</groupId>
+        <artifactId>junit-jupiter</artifactId>
+        <version>${junit.version}</version>
+        <scope>test</scope>
+      </dependency>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-framework-bom</artifactId>
+        <version>6.0.13</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.9</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	})
+	service := filepath.Join(root, "service")
+	if err := os.Mkdir(service, 0755); err != nil {
+		t.Fatalf("failed to create module dir: %v", err)
+	}
+	pom := `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>service</artifactId>
+  <properties>
+    <jackson.version>2.16.1</jackson.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>jakarta.servlet</groupId>
+      <artifactId>jakarta.servlet-api</artifactId>
+      <version>[6.0,7.0)</version>
+      <scope>provided</scope>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>common</artifactId>
+      <version>${project.version}</version>
+      <optional>true</optional>
+    </dependency>
+  </dependencies>
+</project>`
+	pomPath := filepath.Join(service, "pom.xml")
+	if err := os.WriteFile(pomPath, []byte(pom), 0644); err != nil {
+		t.Fatalf("failed to write pom.xml: %v", err)
+	}
+
+	repo := t.TempDir()
+	bomDir := filepath.Join(repo, "org", "springframework", "spring-framework-bom", "6.0.13")
+	if err := os.MkdirAll(bomDir, 0755); err != nil {
+		t.Fatalf("failed to create local repository: %v", err)
+	}
+	bom := `<project>
+  <groupId>org.springframework</groupId>
+  <artifactId>spring-framework-bom</artifactId>
+  <version>6.0.13</version>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-core</artifactId>
+        <version>${project.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+	if err := os.WriteFile(filepath.Join(bomDir, "spring-framework-bom-6.0.13.pom"), []byte(bom), 0644); err != nil {
+		t.Fatalf("failed to write BOM: %v", err)
+	}
+	oldRepo := MavenLocalRepository
+	MavenLocalRepository = repo
+	defer func() { MavenLocalRepository = oldRepo }()
+
+	deps, err := ParsePomFile(pomPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 6 {
+		t.Fatalf("expected 6 dependencies, got %+v", deps)
+	}
+	if d := byName["com.fasterxml.jackson.core:jackson-databind"]; d.Version != "2.16.1" || d.Groups[0] != "compile" {
+		t.Errorf("unexpected jackson-databind: %+v", d)
+	}
+	if d := byName["org.junit.jupiter:junit-jupiter"]; d.Version != "5.10.0" || d.Scope != model.ScopeDev {
+		t.Errorf("unexpected junit-jupiter: %+v", d)
+	}
+	if d := byName["org.springframework:spring-core"]; d.Version != "6.0.13" {
+		t.Errorf("unexpected spring-core: %+v", d)
+	}
+	if d := byName["jakarta.servlet:jakarta.servlet-api"]; d.Version != "" || d.Range != "[6.0,7.0)" || d.Groups[0] != "provided" {
+		t.Errorf("unexpected servlet-api: %+v", d)
+	}
+	if d := byName["com.example:common"]; d.Version != "1.0.0" || !d.Optional {
+		t.Errorf("unexpected common: %+v", d)
+	}
+	if d := byName["org.slf4j:slf4j-api"]; d.Version != "2.0.9" {
+		t.Errorf("unexpected inherited slf4j-api: %+v", d)
+	}
+}
+
+func TestParsePomFile_MissingFile(t *testing.T) {
+	_, err := ParsePomFile("nonexistent.xml")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParsePomFile_Malformed(t *testing.T) {
+	_, err := ParsePomFile(writeTempLock(t, "pom.xml", "<project><dependencies>"))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
@@ -5,18 +5,49 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
 )
 
+// NpmDependency is an entry of the legacy (lockfileVersion 1) "dependencies" object.
 type NpmDependency struct {
-	Version string `json:"version"`
+	Version   string `json:"version"`
+	Resolved  string `json:"resolved"`
+	Integrity string `json:"integrity"`
+	Dev       bool   `json:"dev"`
+	Optional  bool   `json:"optional"`
+}
+
+// NpmPackage is an entry of the "packages" map written by npm 7+ (lockfileVersion 2 and 3).
+// The key "" describes the root project, keys without "node_modules/" describe workspaces.
+type NpmPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Resolved             string            `json:"resolved"`
+	Integrity            string            `json:"integrity"`
+	License              json.RawMessage   `json:"license"`
+	Dev                  bool              `json:"dev"`
+	DevOptional          bool              `json:"devOptional"`
+	Optional             bool              `json:"optional"`
+	Peer                 bool              `json:"peer"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
 }
 
 type NpmLockFile struct {
-	Dependencies map[string]NpmDependency `json:"dependencies"`
+	LockfileVersion int                      `json:"lockfileVersion"`
+	Packages        map[string]NpmPackage    `json:"packages"`
+	Dependencies    map[string]NpmDependency `json:"dependencies"`
 }
 
-// ParseNpmLockFile parses a package-lock.json file and returns a map of dependency names to their versions.
-func ParseNpmLockFile(path string) (map[string]string, error) {
+// ParseNpmLockFile parses a package-lock.json file and returns the dependencies it locks.
+// The "packages" map is used when present, falling back to the legacy "dependencies" object.
+func ParseNpmLockFile(path string) ([]model.Dependency, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open package-lock.json: %w", err)
@@ -33,9 +64,111 @@ func ParseNpmLockFile(path string) (map[string]string, error) {
 		return nil, fmt.Errorf("failed to parse package-lock.json: %w", err)
 	}
 
-	deps := make(map[string]string)
+	if len(lock.Packages) > 0 {
+		return npmPackagesDependencies(path, lock.Packages), nil
+	}
+
+	deps := []model.Dependency{}
 	for name, dep := range lock.Dependencies {
-		deps[name] = dep.Version
+		scope := model.ScopeProd
+		if dep.Dev {
+			scope = model.ScopeDev
+		}
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "npm",
+			Name:         name,
+			ManifestPath: path,
+			Scope:        scope,
+			Optional:     dep.Optional,
+			Version:      dep.Version,
+			Integrity:    dep.Integrity,
+			Registry:     dep.Resolved,
+		})
 	}
 	return deps, nil
 }
+
+// npmPackagesDependencies converts the "packages" map into dependencies. Workspace
+// folders and links to them are local code and are not reported; the ranges they
+// declare mark the matching node_modules entries as direct dependencies.
+func npmPackagesDependencies(path string, packages map[string]NpmPackage) []model.Dependency {
+	// The root project comes first so its ranges win over workspace ones.
+	workspaces := []string{""}
+	for key := range packages {
+		if key != "" && !strings.Contains(key, "node_modules/") {
+			workspaces = append(workspaces, key)
+		}
+	}
+	sort.Strings(workspaces)
+
+	deps := []model.Dependency{}
+	for key, pkg := range packages {
+		idx := strings.LastIndex(key, "node_modules/")
+		if idx < 0 || pkg.Link {
+			continue
+		}
+		installName := key[idx+len("node_modules/"):]
+		name := installName
+		if pkg.Name != "" {
+			// Aliased installs ("alias": "npm:real@^1") record the real name.
+			name = pkg.Name
+		}
+
+		dep := model.Dependency{
+			Ecosystem:    "npm",
+			Name:         name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Optional:     pkg.Optional || pkg.DevOptional,
+			Peer:         pkg.Peer,
+			Version:      pkg.Version,
+			Integrity:    pkg.Integrity,
+			Registry:     pkg.Resolved,
+			License:      npmLicense(pkg.License),
+		}
+		if pkg.Dev || pkg.DevOptional {
+			dep.Scope = model.ScopeDev
+		}
+
+		// Only packages installed directly below the root or a workspace folder
+		// can be direct dependencies. Workspace dependencies are usually hoisted
+		// to the root node_modules, so the root checks every workspace too.
+		parent := strings.TrimSuffix(key[:idx], "/")
+		owners := []string{parent}
+		if parent == "" {
+			owners = workspaces
+		}
+		for _, owner := range owners {
+			pkg, ok := packages[owner]
+			if !ok || strings.Contains(owner, "node_modules/") {
+				continue
+			}
+			if r, ok := npmDeclaredRange(pkg, installName); ok {
+				dep.Direct = true
+				_, dep.Range = packageJSONRange(installName, r)
+				break
+			}
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// npmDeclaredRange returns the range a root or workspace package declares for name.
+func npmDeclaredRange(pkg NpmPackage, name string) (string, bool) {
+	for _, declared := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies, pkg.PeerDependencies} {
+		if r, ok := declared[name]; ok {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// npmLicense returns the license if it was recorded as a plain SPDX string.
+func npmLicense(raw json.RawMessage) string {
+	var license string
+	if len(raw) == 0 || json.Unmarshal(raw, &license) != nil {
+		return ""
+	}
+	return license
+}
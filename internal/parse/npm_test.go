@@ -2,7 +2,10 @@ package parse
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
 )
 
 func TestParseNpmLockFile_Valid(t *testing.T) {
@@ -17,15 +20,89 @@ func TestParseNpmLockFile_Valid(t *testing.T) {
 	}
 	tmp.Close()
 
-	deps, err := ParseNpmLockFile(tmp.Name())
+	list, err := ParseNpmLockFile(tmp.Name())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	deps := versions(list)
 	if deps["express"] != "4.18.2" || deps["lodash"] != "4.17.21" {
 		t.Errorf("unexpected deps: %v", deps)
 	}
 }
 
+func TestParseNpmLockFile_Metadata(t *testing.T) {
+	json := `{"dependencies": {"jest": {"version": "29.7.0", "dev": true, "resolved": "https://registry.npmjs.org/jest/-/jest-29.7.0.tgz", "integrity": "sha512-abc"}}}`
+	path := filepath.Join(t.TempDir(), "package-lock.json")
+	if err := os.WriteFile(path, []byte(json), 0644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	deps, err := ParseNpmLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 1 {
+		t.Fatalf("expected 1 dependency, got %v", deps)
+	}
+	d := deps[0]
+	if d.Scope != model.ScopeDev || d.Integrity != "sha512-abc" || d.Registry == "" || d.Ecosystem != "npm" {
+		t.Errorf("unexpected dependency: %+v", d)
+	}
+}
+
+func TestParseNpmLockFile_PackagesV3(t *testing.T) {
+	json := `{
+  "name": "root",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {
+      "name": "root",
+      "workspaces": ["packages/*"],
+      "dependencies": {"lodash": "^4.17.0"},
+      "devDependencies": {"jest": "^29.0.0"}
+    },
+    "node_modules/lodash": {"version": "4.17.21", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", "integrity": "sha512-lodash", "license": "MIT"},
+    "node_modules/jest": {"version": "29.7.0", "dev": true},
+    "node_modules/jest/node_modules/lodash": {"version": "3.10.1", "dev": true},
+    "node_modules/fsevents": {"version": "2.3.3", "optional": true},
+    "node_modules/react": {"version": "18.2.0", "peer": true},
+    "node_modules/@scope/app": {"resolved": "packages/app", "link": true},
+    "packages/app": {"name": "@scope/app", "version": "1.0.0", "dependencies": {"react": "^18.0.0"}}
+  }
+}`
+	path := filepath.Join(t.TempDir(), "package-lock.json")
+	if err := os.WriteFile(path, []byte(json), 0644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	deps, err := ParseNpmLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 5 {
+		t.Fatalf("expected 5 dependencies, got %d: %+v", len(deps), deps)
+	}
+	byVersion := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byVersion[d.Name+"@"+d.Version] = d
+	}
+	lodash := byVersion["lodash@4.17.21"]
+	if !lodash.Direct || lodash.Range != "^4.17.0" || lodash.License != "MIT" || lodash.Integrity != "sha512-lodash" || lodash.Scope != model.ScopeProd {
+		t.Errorf("unexpected lodash: %+v", lodash)
+	}
+	nested := byVersion["lodash@3.10.1"]
+	if nested.Direct || nested.Scope != model.ScopeDev {
+		t.Errorf("unexpected nested lodash: %+v", nested)
+	}
+	if jest := byVersion["jest@29.7.0"]; !jest.Direct || jest.Scope != model.ScopeDev {
+		t.Errorf("unexpected jest: %+v", jest)
+	}
+	if fsevents := byVersion["fsevents@2.3.3"]; fsevents.Direct || !fsevents.Optional {
+		t.Errorf("unexpected fsevents: %+v", fsevents)
+	}
+	if react := byVersion["react@18.2.0"]; !react.Direct || !react.Peer || react.Range != "^18.0.0" {
+		t.Errorf("unexpected react: %+v", react)
+	}
+}
+
 func TestParseNpmLockFile_MissingFile(t *testing.T) {
 	_, err := ParseNpmLockFile("nonexistent.json")
 	if err == nil {
@@ -50,3 +127,12 @@ func TestParseNpmLockFile_Malformed(t *testing.T) {
 		t.Error("expected error for malformed file, got nil")
 	}
 }
+
+// versions maps dependency names to their resolved versions.
+func versions(deps []model.Dependency) map[string]string {
+	m := make(map[string]string)
+	for _, d := range deps {
+		m[d.Name] = d.Version
+	}
+	return m
+}
@@ -0,0 +1,239 @@
+package parse
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// NuGetLockFile is the content of a packages.lock.json file: the packages
+// restored for each target framework, keyed by framework and package id.
+type NuGetLockFile struct {
+	Version      int                                  `json:"version"`
+	Dependencies map[string]map[string]NuGetLockEntry `json:"dependencies"`
+}
+
+// NuGetLockEntry is a package restored for one target framework.
+type NuGetLockEntry struct {
+	Type         string            `json:"type"` // Direct, Transitive, CentralTransitive or Project
+	Requested    string            `json:"requested"`
+	Resolved     string            `json:"resolved"`
+	ContentHash  string            `json:"contentHash"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// NuGetPackagesProps is the central package management file MSBuild looks up
+// from a project's directory towards the file system root.
+const NuGetPackagesProps = "Directory.Packages.props"
+
+// ParseNuGetProject returns the dependencies of a .NET project from either its
+// packages.lock.json or a .csproj file.
+func ParseNuGetProject(path string) ([]model.Dependency, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csproj") {
+		return ParseCsprojFile(path)
+	}
+	return ParseNuGetLockFile(path)
+}
+
+// ParseNuGetLockFile parses a packages.lock.json file. A package resolved to the
+// same version for several target frameworks is returned once with all
+// frameworks in Groups. Project references are skipped.
+func ParseNuGetLockFile(path string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read packages.lock.json: %w", err)
+	}
+	var lock NuGetLockFile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse packages.lock.json: %w", err)
+	}
+
+	deps := []model.Dependency{}
+	index := make(map[string]int)
+	for _, framework := range sortedKeys(lock.Dependencies) {
+		packages := lock.Dependencies[framework]
+		for _, id := range sortedKeys(packages) {
+			entry := packages[id]
+			if entry.Type == "Project" {
+				continue
+			}
+			key := strings.ToLower(id) + "@" + entry.Resolved
+			i, ok := index[key]
+			if !ok {
+				i = len(deps)
+				index[key] = i
+				deps = append(deps, model.Dependency{
+					Ecosystem:    "nuget",
+					Name:         id,
+					ManifestPath: path,
+					Scope:        model.ScopeProd,
+					Version:      entry.Resolved,
+					Integrity:    entry.ContentHash,
+				})
+			}
+			dep := &deps[i]
+			dep.Groups = append(dep.Groups, framework)
+			if entry.Type == "Direct" {
+				dep.Direct = true
+				dep.Range = entry.Requested
+			}
+		}
+	}
+	return deps, nil
+}
+
+type msbuildProject struct {
+	PropertyGroups []struct {
+		Properties []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences       []msbuildPackageItem `xml:"PackageReference"`
+		PackageVersions         []msbuildPackageItem `xml:"PackageVersion"`
+		GlobalPackageReferences []msbuildPackageItem `xml:"GlobalPackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// msbuildPackageItem is a PackageReference, PackageVersion or GlobalPackageReference.
+// Metadata may be written as attributes or as child elements.
+type msbuildPackageItem struct {
+	Include           string `xml:"Include,attr"`
+	Update            string `xml:"Update,attr"`
+	Version           string `xml:"Version,attr"`
+	VersionOverride   string `xml:"VersionOverride,attr"`
+	PrivateAssets     string `xml:"PrivateAssets,attr"`
+	VersionElem       string `xml:"Version"`
+	PrivateAssetsElem string `xml:"PrivateAssets"`
+}
+
+func (i msbuildPackageItem) id() string { return firstNonEmpty(i.Include, i.Update) }
+
+var msbuildPropertyRe = regexp.MustCompile(`\$\(([A-Za-z_][\w.-]*)\)`)
+
+func readMSBuildProject(path string) (*msbuildProject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	var project msbuildProject
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return &project, nil
+}
+
+// FindNuGetPackagesProps returns the Directory.Packages.props that applies to
+// projects in dir, or "" when central package management is not used.
+func FindNuGetPackagesProps(dir string) string {
+	for {
+		path := filepath.Join(dir, NuGetPackagesProps)
+		if fileExists(path) {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ParseCsprojFile returns the PackageReference items of a .csproj file. Versions
+// missing from the project are taken from Directory.Packages.props, whose
+// GlobalPackageReference items are added as development dependencies. $(Property)
+// references are resolved from both files. References with PrivateAssets="all"
+// do not flow to consumers and are scoped dev.
+func ParseCsprojFile(path string) ([]model.Dependency, error) {
+	project, err := readMSBuildProject(path)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	central := make(map[string]string)
+	var global []msbuildPackageItem
+	if propsPath := FindNuGetPackagesProps(filepath.Dir(path)); propsPath != "" {
+		props, err := readMSBuildProject(propsPath)
+		if err != nil {
+			return nil, err
+		}
+		addMSBuildProperties(properties, props)
+		for _, group := range props.ItemGroups {
+			for _, item := range group.PackageVersions {
+				central[strings.ToLower(item.id())] = firstNonEmpty(item.Version, item.VersionElem)
+			}
+			global = append(global, group.GlobalPackageReferences...)
+		}
+	}
+	addMSBuildProperties(properties, project)
+	expand := func(s string) string {
+		return msbuildPropertyRe.ReplaceAllStringFunc(s, func(ref string) string {
+			return properties[strings.ToLower(ref[2:len(ref)-1])]
+		})
+	}
+
+	deps := []model.Dependency{}
+	seen := make(map[string]bool)
+	add := func(item msbuildPackageItem, scope model.Scope) {
+		id := item.id()
+		if id == "" || seen[strings.ToLower(id)] {
+			return
+		}
+		seen[strings.ToLower(id)] = true
+		rng := expand(firstNonEmpty(item.VersionOverride, item.Version, item.VersionElem, central[strings.ToLower(id)]))
+		if strings.EqualFold(firstNonEmpty(item.PrivateAssets, item.PrivateAssetsElem), "all") {
+			scope = model.ScopeDev
+		}
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "nuget",
+			Name:         id,
+			ManifestPath: path,
+			Direct:       true,
+			Scope:        scope,
+			Range:        rng,
+			Version:      nugetExactVersion(rng),
+		})
+	}
+	for _, group := range project.ItemGroups {
+		for _, item := range group.PackageReferences {
+			add(item, model.ScopeProd)
+		}
+	}
+	for _, item := range global {
+		add(item, model.ScopeDev)
+	}
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+// addMSBuildProperties records the properties of a project; MSBuild property
+// names are case-insensitive.
+func addMSBuildProperties(properties map[string]string, project *msbuildProject) {
+	for _, group := range project.PropertyGroups {
+		for _, p := range group.Properties {
+			properties[strings.ToLower(p.XMLName.Local)] = strings.TrimSpace(p.Value)
+		}
+	}
+}
+
+// nugetExactVersion returns the version a NuGet version range restores to
+// when it names one: "1.2.3" (a minimum NuGet satisfies with that version)
+// or "[1.2.3]". Floating and open ranges return "".
+func nugetExactVersion(rng string) string {
+	rng = strings.TrimSpace(rng)
+	if strings.HasPrefix(rng, "[") && strings.HasSuffix(rng, "]") && !strings.Contains(rng, ",") {
+		return strings.TrimSpace(rng[1 : len(rng)-1])
+	}
+	if rng == "" || strings.ContainsAny(rng, "[](),*$") {
+		return ""
+	}
+	return rng
+}
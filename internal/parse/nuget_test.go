@@ -0,0 +1,114 @@
+package parse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParseNuGetLockFile_Valid(t *testing.T) {
+	path := writeTempLock(t, "packages.lock.json", `{
+  "version": 1,
+  "dependencies": {
+    "net6.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3", "contentHash": "HrC5BXdl"},
+      "System.Memory": {"type": "Transitive", "resolved": "4.5.4", "contentHash": "1MbJTHS1"},
+      "Shared.Core": {"type": "Project", "dependencies": {"Newtonsoft.Json": "[13.0.3, )"}}
+    },
+    "net8.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.3, )", "resolved": "13.0.3", "contentHash": "HrC5BXdl"},
+      "System.Memory": {"type": "CentralTransitive", "requested": "[4.5.5, )", "resolved": "4.5.5", "contentHash": "XIWiDvKP"}
+    }
+  }
+}`)
+	deps, err := ParseNuGetLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 3 {
+		t.Fatalf("expected 3 packages, got %d: %+v", len(deps), deps)
+	}
+	json := deps[0]
+	if json.Name != "Newtonsoft.Json" || !json.Direct || json.Range != "[13.0.3, )" || len(json.Groups) != 2 || json.Integrity != "HrC5BXdl" {
+		t.Errorf("unexpected Newtonsoft.Json: %+v", json)
+	}
+	for _, d := range deps[1:] {
+		if d.Name != "System.Memory" || d.Direct || len(d.Groups) != 1 {
+			t.Errorf("unexpected System.Memory: %+v", d)
+		}
+	}
+}
+
+func TestParseCsprojFile_CentralPackageManagement(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"Directory.Packages.props": `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+    <SerilogVersion>3.1.1</SerilogVersion>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Serilog" Version="$(SerilogVersion)" />
+    <PackageVersion Include="Newtonsoft.Json" Version="13.0.1" />
+    <GlobalPackageReference Include="StyleCop.Analyzers" Version="1.1.118" />
+  </ItemGroup>
+</Project>`,
+		"src/App/App.csproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Serilog" />
+    <PackageReference Include="Newtonsoft.Json" VersionOverride="13.0.3" />
+    <PackageReference Include="Polly">
+      <Version>[8.0.0, 9.0.0)</Version>
+    </PackageReference>
+    <PackageReference Include="coverlet.collector" Version="[6.0.0]" PrivateAssets="all" />
+  </ItemGroup>
+</Project>`,
+	})
+	deps, err := ParseNuGetProject(filepath.Join(dir, "src", "App", "App.csproj"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 5 {
+		t.Fatalf("expected 5 references, got %d: %+v", len(deps), deps)
+	}
+	if d := byName["Serilog"]; d.Version != "3.1.1" || !d.Direct {
+		t.Errorf("unexpected Serilog: %+v", d)
+	}
+	if d := byName["Newtonsoft.Json"]; d.Version != "13.0.3" {
+		t.Errorf("expected VersionOverride to win, got %+v", d)
+	}
+	if d := byName["Polly"]; d.Version != "" || d.Range != "[8.0.0, 9.0.0)" {
+		t.Errorf("unexpected Polly: %+v", d)
+	}
+	if d := byName["coverlet.collector"]; d.Version != "6.0.0" || d.Scope != model.ScopeDev {
+		t.Errorf("unexpected coverlet.collector: %+v", d)
+	}
+	if d := byName["StyleCop.Analyzers"]; d.Version != "1.1.118" || d.Scope != model.ScopeDev {
+		t.Errorf("unexpected StyleCop.Analyzers: %+v", d)
+	}
+}
+
+func TestParseNuGetLockFile_FileNotFound(t *testing.T) {
+	_, err := ParseNuGetLockFile("nonexistent/packages.lock.json")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseNuGetProject_Malformed(t *testing.T) {
+	path := writeTempLock(t, "packages.lock.json", `{"dependencies": {`)
+	if _, err := ParseNuGetProject(path); err == nil {
+		t.Error("expected error for malformed lock file, got nil")
+	}
+	path = writeTempLock(t, "App.csproj", `<Project><ItemGroup>`)
+	if _, err := ParseNuGetProject(path); err == nil {
+		t.Error("expected error for malformed project file, got nil")
+	}
+}
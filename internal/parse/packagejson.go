@@ -0,0 +1,116 @@
+package parse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"github.com/cyber-kamil/depflow/internal/version"
+)
+
+// PackageJSON holds the dependency ranges a package.json declares.
+type PackageJSON struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// ReadPackageJSON reads the package.json at path.
+func ReadPackageJSON(path string) (*PackageJSON, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+	var pkg PackageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+	return &pkg, nil
+}
+
+// markPackageJSONDirect marks the dependencies declared by the package.json next
+// to lockPath as direct and sets their range to the declared one. Locked
+// entries are matched by name and by the requested ranges in their Range, so
+// the right copy is picked when several versions are locked. A missing
+// package.json leaves deps untouched.
+func markPackageJSONDirect(lockPath string, deps []model.Dependency) error {
+	pkg, err := ReadPackageJSON(filepath.Join(filepath.Dir(lockPath), "package.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sections := []struct {
+		declared map[string]string
+		scope    model.Scope
+		optional bool
+	}{
+		{pkg.Dependencies, model.ScopeProd, false},
+		{pkg.DevDependencies, model.ScopeDev, false},
+		{pkg.OptionalDependencies, model.ScopeProd, true},
+		{pkg.PeerDependencies, model.ScopeProd, false},
+	}
+	for _, section := range sections {
+		for alias, declared := range section.declared {
+			name, rng := packageJSONRange(alias, declared)
+			for i := range deps {
+				dep := &deps[i]
+				if dep.Direct || dep.Name != name || !containsRange(dep.Range, rng) {
+					continue
+				}
+				dep.Direct = true
+				dep.Range = rng
+				dep.Scope = section.scope
+				dep.Optional = section.optional
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// packageJSONRange returns the registry package and range a package.json
+// declaration resolves, following "npm:" protocols and aliases such as
+// "npm:real@^1.0.0" or "npm:@scope/real". It is used for every lock format
+// recording declared ranges.
+func packageJSONRange(alias, declared string) (name, rng string) {
+	rest, ok := strings.CutPrefix(declared, "npm:")
+	if !ok {
+		return alias, declared
+	}
+	// The version separator is the "@" after the name, never a scope's "@".
+	if at := strings.LastIndex(rest, "@"); at > 0 {
+		return rest[:at], rest[at+1:]
+	}
+	if strings.HasPrefix(rest, "@") {
+		return rest, ""
+	}
+	// Yarn berry writes plain ranges as "npm:^1.0.0"; anything else names
+	// the aliased package without a range.
+	if _, err := version.ParseNpmRange(rest); err == nil {
+		return alias, rest
+	}
+	return rest, ""
+}
+
+// containsRange reports whether ranges, a " || " union of requested ranges,
+// includes every alternative of rng.
+func containsRange(ranges, rng string) bool {
+	requested := make(map[string]bool)
+	for _, r := range strings.Split(ranges, " || ") {
+		requested[strings.TrimSpace(r)] = true
+	}
+	for _, r := range strings.Split(rng, "||") {
+		if !requested[strings.TrimSpace(r)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package parse
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseYarnLockFile_PackageJSON(t *testing.T) {
+	lock := `debug@^3.2.7:
+  version "3.2.7"
+
+debug@^4.1.0, debug@^4.3.1:
+  version "4.3.4"
+
+"string-width-cjs@npm:string-width@^4.2.0", string-width@^4.1.0:
+  version "4.2.3"
+
+jest@^29.0.0:
+  version "29.7.0"
+`
+	path := writeProject(t, map[string]string{
+		"yarn.lock": lock,
+		"package.json": `{
+			"dependencies": {"debug": "^4.1.0", "string-width-cjs": "npm:string-width@^4.2.0"},
+			"devDependencies": {"jest": "^29.0.0"}
+		}`,
+	})
+	deps, err := ParseYarnLockFile(filepath.Join(path, "yarn.lock"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := []string{}
+	for _, d := range deps {
+		if d.Direct {
+			got = append(got, d.Name+"@"+d.Version+" "+d.Range+" "+string(d.Scope))
+		}
+	}
+	want := "debug@4.3.4 ^4.1.0 prod, string-width@4.2.3 ^4.2.0 prod, jest@29.7.0 ^29.0.0 dev"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("unexpected direct dependencies:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestParseYarnBerryLockFile_PackageJSON(t *testing.T) {
+	lock := `__metadata:
+  version: 6
+
+"debug@npm:^4.1.0, debug@npm:^4.3.1":
+  version: 4.3.4
+  resolution: "debug@npm:4.3.4"
+
+"typescript@npm:~5.1.0 || ~5.2.0":
+  version: 5.2.2
+  resolution: "typescript@npm:5.2.2"
+`
+	path := writeProject(t, map[string]string{
+		"yarn.lock":    lock,
+		"package.json": `{"dependencies": {"debug": "npm:^4.3.1"}, "optionalDependencies": {"typescript": "~5.1.0 || ~5.2.0"}}`,
+	})
+	deps, err := ParseYarnBerryLockFile(filepath.Join(path, "yarn.lock"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range deps {
+		switch {
+		case !d.Direct:
+			t.Errorf("%s not marked direct", d.Name)
+		case d.Name == "debug" && d.Range != "^4.3.1":
+			t.Errorf("unexpected debug range %q", d.Range)
+		case d.Name == "typescript" && !d.Optional:
+			t.Errorf("typescript not marked optional")
+		}
+	}
+}
+
+func TestReadPackageJSON_Malformed(t *testing.T) {
+	path := writeTempLock(t, "package.json", "{")
+	if _, err := ReadPackageJSON(path); err == nil {
+		t.Error("expected error for malformed package.json")
+	}
+}
+
+func TestPackageJSONRange(t *testing.T) {
+	cases := []struct {
+		alias, declared, name, rng string
+	}{
+		{"lodash", "^4.17.0", "lodash", "^4.17.0"},
+		{"lodash", "npm:^4.17.0", "lodash", "^4.17.0"},
+		{"old-lodash", "npm:lodash@^3.10.0", "lodash", "^3.10.0"},
+		{"my-lodash", "npm:lodash", "lodash", ""},
+		{"pkg", "npm:@scope/pkg", "@scope/pkg", ""},
+		{"pkg", "npm:@scope/pkg@~2.1.0", "@scope/pkg", "~2.1.0"},
+	}
+	for _, c := range cases {
+		name, rng := packageJSONRange(c.alias, c.declared)
+		if name != c.name || rng != c.rng {
+			t.Errorf("packageJSONRange(%q, %q) = %q, %q, want %q, %q", c.alias, c.declared, name, rng, c.name, c.rng)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// PipfileLockPackage is an entry of the "default" or "develop" section of Pipfile.lock.
+type PipfileLockPackage struct {
+	Version  string   `json:"version"`
+	Hashes   []string `json:"hashes"`
+	Markers  string   `json:"markers"`
+	Index    string   `json:"index"`
+	Extras   []string `json:"extras"`
+	Editable bool     `json:"editable"`
+	Path     string   `json:"path"`
+	Git      string   `json:"git"`
+}
+
+type PipfileLock struct {
+	Meta struct {
+		Sources []struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"sources"`
+	} `json:"_meta"`
+	Default map[string]PipfileLockPackage `json:"default"`
+	Develop map[string]PipfileLockPackage `json:"develop"`
+}
+
+// ParsePipfileLock parses a Pipfile.lock file and returns the packages it locks.
+// Packages from "default" are scoped prod, packages from "develop" dev. Editable,
+// path and VCS packages are skipped.
+func ParsePipfileLock(path string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Pipfile.lock: %w", err)
+	}
+	var lock PipfileLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse Pipfile.lock: %w", err)
+	}
+
+	sources := make(map[string]string)
+	for _, src := range lock.Meta.Sources {
+		sources[src.Name] = src.URL
+	}
+
+	deps := []model.Dependency{}
+	sections := []struct {
+		packages map[string]PipfileLockPackage
+		scope    model.Scope
+	}{
+		{lock.Default, model.ScopeProd},
+		{lock.Develop, model.ScopeDev},
+	}
+	for _, section := range sections {
+		for name, pkg := range section.packages {
+			if pkg.Version == "" || pkg.Editable || pkg.Path != "" || pkg.Git != "" {
+				continue
+			}
+			registry := sources[pkg.Index]
+			if registry == "" {
+				registry = pkg.Index
+			}
+			deps = append(deps, model.Dependency{
+				Ecosystem:    "pipenv",
+				Name:         name,
+				ManifestPath: path,
+				Scope:        section.scope,
+				Extras:       pkg.Extras,
+				Markers:      pkg.Markers,
+				Range:        pkg.Version,
+				Version:      strings.TrimLeft(pkg.Version, "="),
+				Integrity:    strings.Join(pkg.Hashes, " "),
+				Registry:     registry,
+			})
+		}
+	}
+	return deps, nil
+}
@@ -0,0 +1,64 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParsePipfileLock_Valid(t *testing.T) {
+	lock := `{
+    "_meta": {
+        "pipfile-spec": 6,
+        "sources": [{"name": "pypi", "url": "https://pypi.org/simple", "verify_ssl": true}]
+    },
+    "default": {
+        "requests": {
+            "hashes": ["sha256:aaa", "sha256:bbb"],
+            "index": "pypi",
+            "markers": "python_version >= '3.7'",
+            "version": "==2.31.0"
+        },
+        "local-pkg": {"editable": true, "path": "."}
+    },
+    "develop": {
+        "pytest": {"hashes": ["sha256:ccc"], "version": "==7.4.3"}
+    }
+}`
+	deps, err := ParsePipfileLock(writeTempLock(t, "Pipfile.lock", lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("expected 2 dependencies, got %+v", deps)
+	}
+	for _, d := range deps {
+		switch d.Name {
+		case "requests":
+			if d.Version != "2.31.0" || d.Scope != model.ScopeProd || d.Integrity != "sha256:aaa sha256:bbb" ||
+				d.Markers != "python_version >= '3.7'" || d.Registry != "https://pypi.org/simple" {
+				t.Errorf("unexpected requests: %+v", d)
+			}
+		case "pytest":
+			if d.Version != "7.4.3" || d.Scope != model.ScopeDev {
+				t.Errorf("unexpected pytest: %+v", d)
+			}
+		default:
+			t.Errorf("unexpected dependency %s", d.Name)
+		}
+	}
+}
+
+func TestParsePipfileLock_MissingFile(t *testing.T) {
+	_, err := ParsePipfileLock("nonexistent.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParsePipfileLock_Malformed(t *testing.T) {
+	_, err := ParsePipfileLock(writeTempLock(t, "Pipfile.lock", `{"default": {"requests": {"version": 2}}}`))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
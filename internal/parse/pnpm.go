@@ -0,0 +1,184 @@
+package parse
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// PnpmImporterDependency is a direct dependency of a pnpm importer (workspace project).
+type PnpmImporterDependency struct {
+	Specifier string `yaml:"specifier"`
+	Version   string `yaml:"version"`
+}
+
+// PnpmImporter lists the direct dependencies of one project in the workspace.
+type PnpmImporter struct {
+	Dependencies         map[string]PnpmImporterDependency `yaml:"dependencies"`
+	DevDependencies      map[string]PnpmImporterDependency `yaml:"devDependencies"`
+	OptionalDependencies map[string]PnpmImporterDependency `yaml:"optionalDependencies"`
+}
+
+// PnpmPackage is an entry of the "packages" (and, since v9, "snapshots") section.
+type PnpmPackage struct {
+	Resolution struct {
+		Integrity string `yaml:"integrity"`
+		Tarball   string `yaml:"tarball"`
+		Type      string `yaml:"type"` // set for git and directory packages
+	} `yaml:"resolution"`
+	Dev      *bool `yaml:"dev"` // only written by lockfile v6
+	Optional bool  `yaml:"optional"`
+}
+
+// PnpmLockFile is a pnpm-lock.yaml file in lockfile format 6 or 9.
+// Single-project v6 lock files keep the root importer's sections at the top level.
+type PnpmLockFile struct {
+	LockfileVersion string                  `yaml:"lockfileVersion"`
+	Importers       map[string]PnpmImporter `yaml:"importers"`
+	Packages        map[string]PnpmPackage  `yaml:"packages"`
+	Snapshots       map[string]PnpmPackage  `yaml:"snapshots"`
+	PnpmImporter    `yaml:",inline"`
+}
+
+// ParsePnpmLockFile parses a pnpm-lock.yaml file and returns its dependencies.
+// Direct dependencies are returned once per importer declaring them, with the
+// importer's package.json as manifest path; other packages are returned once.
+func ParsePnpmLockFile(path string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pnpm-lock.yaml: %w", err)
+	}
+	var lock PnpmLockFile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse pnpm-lock.yaml: %w", err)
+	}
+	major, err := strconv.ParseFloat(lock.LockfileVersion, 64)
+	if err != nil || major < 6 {
+		return nil, fmt.Errorf("unsupported pnpm-lock.yaml lockfileVersion %q", lock.LockfileVersion)
+	}
+	if len(lock.Importers) == 0 {
+		lock.Importers = map[string]PnpmImporter{".": lock.PnpmImporter}
+	}
+
+	packages := make(map[string]PnpmPackage)
+	for key, pkg := range lock.Packages {
+		name, version := splitPnpmKey(key)
+		packages[name+"@"+version] = pkg
+	}
+	for key, pkg := range lock.Snapshots {
+		name, version := splitPnpmKey(key)
+		if pkg.Optional {
+			p := packages[name+"@"+version]
+			p.Optional = true
+			packages[name+"@"+version] = p
+		}
+	}
+
+	importers := make([]string, 0, len(lock.Importers))
+	for importer := range lock.Importers {
+		importers = append(importers, importer)
+	}
+	sort.Strings(importers)
+
+	deps := []model.Dependency{}
+	direct := make(map[string]bool)
+	for _, importer := range importers {
+		imp := lock.Importers[importer]
+		manifest := filepath.Join(filepath.Dir(path), importer, "package.json")
+		sections := []struct {
+			deps  map[string]PnpmImporterDependency
+			scope model.Scope
+		}{
+			{imp.Dependencies, model.ScopeProd},
+			{imp.DevDependencies, model.ScopeDev},
+			{imp.OptionalDependencies, model.ScopeProd},
+		}
+		for _, section := range sections {
+			for alias, d := range section.deps {
+				name, version, ok := pnpmImporterVersion(alias, d.Version)
+				if !ok {
+					continue
+				}
+				key := name + "@" + version
+				pkg := packages[key]
+				direct[key] = true
+				_, rng := packageJSONRange(alias, d.Specifier)
+				deps = append(deps, model.Dependency{
+					Ecosystem:    "pnpm",
+					Name:         name,
+					ManifestPath: manifest,
+					Direct:       true,
+					Scope:        section.scope,
+					Optional:     pkg.Optional,
+					Range:        rng,
+					Version:      version,
+					Integrity:    pkg.Resolution.Integrity,
+					Registry:     pkg.Resolution.Tarball,
+				})
+			}
+		}
+	}
+
+	for key, pkg := range packages {
+		if direct[key] || pkg.Resolution.Type != "" {
+			continue
+		}
+		name, version := splitPnpmKey(key)
+		scope := model.ScopeProd
+		if pkg.Dev != nil && *pkg.Dev {
+			scope = model.ScopeDev
+		}
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "pnpm",
+			Name:         name,
+			ManifestPath: path,
+			Scope:        scope,
+			Optional:     pkg.Optional,
+			Version:      version,
+			Integrity:    pkg.Resolution.Integrity,
+			Registry:     pkg.Resolution.Tarball,
+		})
+	}
+	return deps, nil
+}
+
+// splitPnpmKey splits a package key into name and version. It accepts the v6
+// form "/@scope/name@1.0.0(peer@2.0.0)" as well as the v9 form without the slash.
+func splitPnpmKey(key string) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+	if paren := strings.Index(key, "("); paren >= 0 {
+		key = key[:paren]
+	}
+	if key == "" {
+		return "", ""
+	}
+	at := strings.Index(key[1:], "@")
+	if at < 0 {
+		return key, ""
+	}
+	return key[:at+1], key[at+2:]
+}
+
+// pnpmImporterVersion returns the package an importer entry resolves to.
+// Linked workspace packages and file dependencies are reported as not ok.
+func pnpmImporterVersion(alias, version string) (name, resolved string, ok bool) {
+	if paren := strings.Index(version, "("); paren >= 0 {
+		version = version[:paren]
+	}
+	if version == "" || strings.HasPrefix(version, "link:") || strings.HasPrefix(version, "file:") {
+		return "", "", false
+	}
+	if version[0] >= '0' && version[0] <= '9' {
+		return alias, version, true
+	}
+	// Aliased dependencies record the real package, e.g. "string-width@4.2.3"
+	// in v9 or "/string-width@4.2.3" in v6.
+	name, resolved = splitPnpmKey(version)
+	return name, resolved, resolved != ""
+}
@@ -0,0 +1,144 @@
+package parse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParsePnpmLockFile_V9(t *testing.T) {
+	lock := `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    devDependencies:
+      typescript:
+        specifier: ^5.0.0
+        version: 5.1.6
+
+  packages/app:
+    dependencies:
+      react-dom:
+        specifier: ^18.2.0
+        version: 18.2.0(react@18.2.0)
+      shared:
+        specifier: workspace:*
+        version: link:../shared
+      string-width-cjs:
+        specifier: npm:string-width@^4.2.0
+        version: string-width@4.2.3
+
+packages:
+
+  react@18.2.0:
+    resolution: {integrity: sha512-react}
+
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-react-dom}
+    peerDependencies:
+      react: ^18.2.0
+
+  string-width@4.2.3:
+    resolution: {integrity: sha512-sw}
+
+  typescript@5.1.6:
+    resolution: {integrity: sha512-ts}
+
+snapshots:
+
+  react@18.2.0: {}
+
+  react-dom@18.2.0(react@18.2.0):
+    dependencies:
+      react: 18.2.0
+
+  string-width@4.2.3: {}
+
+  typescript@5.1.6: {}
+`
+	path := writeTempLock(t, "pnpm-lock.yaml", lock)
+	deps, err := ParsePnpmLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 dependencies, got %+v", deps)
+	}
+	ts := byName["typescript"]
+	if !ts.Direct || ts.Scope != model.ScopeDev || ts.Range != "^5.0.0" || ts.ManifestPath != filepath.Join(filepath.Dir(path), "package.json") {
+		t.Errorf("unexpected typescript: %+v", ts)
+	}
+	rd := byName["react-dom"]
+	if !rd.Direct || rd.Version != "18.2.0" || rd.Integrity != "sha512-react-dom" || rd.ManifestPath != filepath.Join(filepath.Dir(path), "packages/app", "package.json") {
+		t.Errorf("unexpected react-dom: %+v", rd)
+	}
+	if sw := byName["string-width"]; !sw.Direct || sw.Version != "4.2.3" || sw.Range != "^4.2.0" {
+		t.Errorf("unexpected string-width: %+v", sw)
+	}
+	if react := byName["react"]; react.Direct || react.ManifestPath != path {
+		t.Errorf("unexpected react: %+v", react)
+	}
+}
+
+func TestParsePnpmLockFile_V6(t *testing.T) {
+	lock := `lockfileVersion: '6.0'
+
+dependencies:
+  '@babel/core':
+    specifier: ^7.22.0
+    version: 7.22.5
+
+packages:
+
+  /@babel/core@7.22.5:
+    resolution: {integrity: sha512-babel}
+    dependencies:
+      debug: 4.3.4
+    dev: false
+
+  /debug@4.3.4:
+    resolution: {integrity: sha512-debug}
+    dev: true
+`
+	deps, err := ParsePnpmLockFile(writeTempLock(t, "pnpm-lock.yaml", lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("expected 2 dependencies, got %+v", deps)
+	}
+	for _, d := range deps {
+		switch d.Name {
+		case "@babel/core":
+			if !d.Direct || d.Version != "7.22.5" || d.Integrity != "sha512-babel" {
+				t.Errorf("unexpected @babel/core: %+v", d)
+			}
+		case "debug":
+			if d.Direct || d.Scope != model.ScopeDev {
+				t.Errorf("unexpected debug: %+v", d)
+			}
+		default:
+			t.Errorf("unexpected dependency %s", d.Name)
+		}
+	}
+}
+
+func TestParsePnpmLockFile_UnsupportedVersion(t *testing.T) {
+	_, err := ParsePnpmLockFile(writeTempLock(t, "pnpm-lock.yaml", "lockfileVersion: 5.4\n"))
+	if err == nil {
+		t.Error("expected error for lockfile v5, got nil")
+	}
+}
+
+func TestParsePnpmLockFile_MissingFile(t *testing.T) {
+	_, err := ParsePnpmLockFile("nonexistent.yaml")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
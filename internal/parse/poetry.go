@@ -0,0 +1,106 @@
+package parse
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// PoetryPackage is a [[package]] entry of poetry.lock.
+type PoetryPackage struct {
+	Name     string   `toml:"name"`
+	Version  string   `toml:"version"`
+	Optional bool     `toml:"optional"`
+	Category string   `toml:"category"` // Poetry 1.x: "main" or "dev"
+	Groups   []string `toml:"groups"`   // Poetry 2.x
+	Files    []struct {
+		File string `toml:"file"`
+		Hash string `toml:"hash"`
+	} `toml:"files"`
+	Source struct {
+		Type      string `toml:"type"` // legacy, git, directory, file, url
+		URL       string `toml:"url"`
+		Reference string `toml:"reference"`
+	} `toml:"source"`
+}
+
+type PoetryLockFile struct {
+	Packages []PoetryPackage `toml:"package"`
+}
+
+// ParsePoetryLockFile parses a poetry.lock file and returns the packages it locks.
+// Declared ranges, groups and extras come from the pyproject.toml next to it.
+// Git, directory, file and URL packages are skipped.
+func ParsePoetryLockFile(path string) ([]model.Dependency, error) {
+	var lock PoetryLockFile
+	if _, err := toml.DecodeFile(path, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse poetry.lock: %w", err)
+	}
+	declared, err := parsePyProjectIfExists(filepath.Join(filepath.Dir(path), "pyproject.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	deps := []model.Dependency{}
+	for _, pkg := range lock.Packages {
+		if pkg.Source.Type != "" && pkg.Source.Type != "legacy" {
+			continue
+		}
+		hashes := []string{}
+		for _, f := range pkg.Files {
+			hashes = append(hashes, f.Hash)
+		}
+		groups := pkg.Groups
+		if len(groups) == 0 && pkg.Category != "" {
+			groups = []string{pkg.Category}
+		}
+		dep := model.Dependency{
+			Ecosystem:    "poetry",
+			Name:         pkg.Name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Optional:     pkg.Optional,
+			Groups:       groups,
+			Version:      pkg.Version,
+			Integrity:    strings.Join(hashes, " "),
+			Registry:     pkg.Source.URL,
+		}
+		if len(groups) > 0 && !containsString(groups, "main") {
+			dep.Scope = model.ScopeDev
+		}
+		applyPyProjectRequirement(&dep, declared)
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// applyPyProjectRequirement marks dep as direct if pyproject.toml declares it.
+func applyPyProjectRequirement(dep *model.Dependency, declared map[string]*PyProjectRequirement) {
+	req, ok := declared[NormalizePythonName(dep.Name)]
+	if !ok {
+		return
+	}
+	dep.Direct = true
+	dep.Range = req.Range
+	for _, g := range req.Groups {
+		if !containsString(dep.Groups, g) {
+			dep.Groups = append(dep.Groups, g)
+		}
+	}
+	dep.Optional = dep.Optional || req.Optional()
+	if req.Dev() {
+		dep.Scope = model.ScopeDev
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// writeProject writes files into a fresh directory and returns its path.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestParsePoetryLockFile_Valid(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"pyproject.toml": `[tool.poetry]
+name = "service"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+requests = {version = "^2.31", extras = ["socks"]}
+PySocks = {version = "^1.7", optional = true}
+
+[tool.poetry.extras]
+socks = ["pysocks"]
+
+[tool.poetry.group.dev.dependencies]
+pytest = "^7.4"
+`,
+		"poetry.lock": `[[package]]
+name = "requests"
+version = "2.31.0"
+optional = false
+python-versions = ">=3.7"
+groups = ["main"]
+files = [
+    {file = "requests-2.31.0-py3-none-any.whl", hash = "sha256:aaa"},
+]
+
+[package.source]
+type = "legacy"
+url = "https://pypi.example.com/simple"
+reference = "private"
+
+[[package]]
+name = "pysocks"
+version = "1.7.1"
+optional = true
+groups = ["main"]
+
+[[package]]
+name = "pytest"
+version = "7.4.3"
+optional = false
+groups = ["dev"]
+
+[[package]]
+name = "iniconfig"
+version = "2.0.0"
+category = "dev"
+optional = false
+
+[[package]]
+name = "local-lib"
+version = "0.1.0"
+optional = false
+
+[package.source]
+type = "directory"
+url = "../local-lib"
+
+[metadata]
+lock-version = "2.1"
+`,
+	})
+	deps, err := ParsePoetryLockFile(filepath.Join(dir, "poetry.lock"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 dependencies, got %+v", deps)
+	}
+	for _, d := range deps {
+		switch d.Name {
+		case "requests":
+			if !d.Direct || d.Range != "^2.31" || d.Registry != "https://pypi.example.com/simple" || d.Integrity != "sha256:aaa" || d.Scope != model.ScopeProd {
+				t.Errorf("unexpected requests: %+v", d)
+			}
+		case "pysocks":
+			if !d.Direct || !d.Optional || !containsString(d.Groups, "extra:socks") {
+				t.Errorf("unexpected pysocks: %+v", d)
+			}
+		case "pytest":
+			if !d.Direct || d.Scope != model.ScopeDev || strings.Join(d.Groups, ",") != "dev" {
+				t.Errorf("unexpected pytest: %+v", d)
+			}
+		case "iniconfig":
+			if d.Direct || d.Scope != model.ScopeDev {
+				t.Errorf("unexpected iniconfig: %+v", d)
+			}
+		default:
+			t.Errorf("unexpected dependency %s", d.Name)
+		}
+	}
+}
+
+func TestParsePoetryLockFile_MissingFile(t *testing.T) {
+	_, err := ParsePoetryLockFile("nonexistent.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParsePoetryLockFile_Malformed(t *testing.T) {
+	_, err := ParsePoetryLockFile(writeTempLock(t, "poetry.lock", "[[package]\nname = \"x\""))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
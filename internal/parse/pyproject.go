@@ -0,0 +1,186 @@
+package parse
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PyProjectRequirement is a dependency declared in pyproject.toml.
+type PyProjectRequirement struct {
+	Name   string
+	Range  string
+	Groups []string // "main" for runtime dependencies, "extra:<name>" for extras
+}
+
+// Optional reports whether the requirement is only pulled in through extras.
+func (r *PyProjectRequirement) Optional() bool {
+	for _, g := range r.Groups {
+		if !strings.HasPrefix(g, "extra:") {
+			return false
+		}
+	}
+	return len(r.Groups) > 0
+}
+
+// Dev reports whether the requirement is only declared in non-runtime groups.
+func (r *PyProjectRequirement) Dev() bool {
+	for _, g := range r.Groups {
+		if g == "main" || strings.HasPrefix(g, "extra:") {
+			return false
+		}
+	}
+	return len(r.Groups) > 0
+}
+
+type pyProjectFile struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// Entries are PEP 508 strings or {include-group = "..."} tables.
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Dependencies    map[string]interface{} `toml:"dependencies"`
+			DevDependencies map[string]interface{} `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+			} `toml:"group"`
+			Extras map[string][]string `toml:"extras"`
+		} `toml:"poetry"`
+		Uv struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+	} `toml:"tool"`
+}
+
+// ParsePyProject reads the dependencies declared in a pyproject.toml file, keyed by
+// normalized name. It understands PEP 621 [project] tables, PEP 735 [dependency-groups],
+// Poetry's [tool.poetry] tables and uv's legacy tool.uv.dev-dependencies.
+func ParsePyProject(path string) (map[string]*PyProjectRequirement, error) {
+	var file pyProjectFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pyproject.toml: %w", err)
+	}
+
+	reqs := make(map[string]*PyProjectRequirement)
+	add := func(name, rng, group string) {
+		key := NormalizePythonName(name)
+		if key == "python" {
+			return
+		}
+		req, ok := reqs[key]
+		if !ok {
+			req = &PyProjectRequirement{Name: name}
+			reqs[key] = req
+		}
+		if req.Range == "" {
+			req.Range = rng
+		}
+		for _, g := range req.Groups {
+			if g == group {
+				return
+			}
+		}
+		req.Groups = append(req.Groups, group)
+	}
+	addPEP508 := func(spec, group string) {
+		if dep, ok := parseRequirement(spec); ok {
+			add(dep.Name, dep.Range, group)
+		}
+	}
+
+	for _, spec := range file.Project.Dependencies {
+		addPEP508(spec, "main")
+	}
+	for _, extra := range sortedKeys(file.Project.OptionalDependencies) {
+		for _, spec := range file.Project.OptionalDependencies[extra] {
+			addPEP508(spec, "extra:"+extra)
+		}
+	}
+	for group, entries := range file.DependencyGroups {
+		for _, entry := range entries {
+			if spec, ok := entry.(string); ok {
+				addPEP508(spec, group)
+			}
+		}
+	}
+	for _, spec := range file.Tool.Uv.DevDependencies {
+		addPEP508(spec, "dev")
+	}
+
+	poetry := file.Tool.Poetry
+	optional := make(map[string][]string)
+	for extra, names := range poetry.Extras {
+		for _, name := range names {
+			key := NormalizePythonName(name)
+			optional[key] = append(optional[key], "extra:"+extra)
+		}
+	}
+	for name, value := range poetry.Dependencies {
+		rng, isOptional := poetryConstraint(value)
+		extras := optional[NormalizePythonName(name)]
+		if !isOptional || len(extras) == 0 {
+			add(name, rng, "main")
+			continue
+		}
+		sort.Strings(extras)
+		for _, extra := range extras {
+			add(name, rng, extra)
+		}
+	}
+	for name, value := range poetry.DevDependencies {
+		rng, _ := poetryConstraint(value)
+		add(name, rng, "dev")
+	}
+	for group, g := range poetry.Group {
+		for name, value := range g.Dependencies {
+			rng, _ := poetryConstraint(value)
+			add(name, rng, group)
+		}
+	}
+	return reqs, nil
+}
+
+// parsePyProjectIfExists reads pyproject.toml in dir, returning nil if there is none.
+func parsePyProjectIfExists(path string) (map[string]*PyProjectRequirement, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return ParsePyProject(path)
+}
+
+// poetryConstraint returns the version constraint of a [tool.poetry.dependencies]
+// value, which is either a string or a table such as {version = "^2.0", optional = true}.
+func poetryConstraint(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, false
+	case map[string]interface{}:
+		rng, _ := v["version"].(string)
+		optional, _ := v["optional"].(bool)
+		return rng, optional
+	case []map[string]interface{}:
+		if len(v) > 0 {
+			return poetryConstraint(v[0])
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			return poetryConstraint(v[0])
+		}
+	}
+	return "", false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
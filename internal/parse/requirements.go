@@ -0,0 +1,224 @@
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+var (
+	requirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+	specifierRe   = regexp.MustCompile(`^(===|==|~=|!=|<=|>=|<|>)\s*(\S+)$`)
+	hashOptionRe  = regexp.MustCompile(`\s--hash[=\s]\s*(\S+)`)
+	pythonNameRe  = regexp.MustCompile(`[-_.]+`)
+)
+
+// ParseRequirementsFile parses a pip requirements file and returns the packages it requires.
+// Files included with -r are read as well; -c constraint files only pin versions of
+// packages that are required elsewhere. Editable installs and VCS, URL and local path
+// requirements are skipped.
+func ParseRequirementsFile(path string) ([]model.Dependency, error) {
+	p := &requirementsParser{seen: make(map[string]bool), constraints: make(map[string]string)}
+	if err := p.parseFile(path, false); err != nil {
+		return nil, err
+	}
+	for i := range p.deps {
+		dep := &p.deps[i]
+		if dep.Version == "" {
+			dep.Version = p.constraints[NormalizePythonName(dep.Name)]
+		}
+	}
+	return p.deps, nil
+}
+
+// NormalizePythonName normalizes a Python package name as described in PEP 503.
+func NormalizePythonName(name string) string {
+	return strings.ToLower(pythonNameRe.ReplaceAllString(name, "-"))
+}
+
+type requirementsParser struct {
+	deps        []model.Dependency
+	constraints map[string]string // normalized name -> pinned version
+	seen        map[string]bool
+}
+
+func (p *requirementsParser) parseFile(path string, constraint bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	if p.seen[abs] {
+		return nil
+	}
+	p.seen[abs] = true
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	index := ""
+	scanner := bufio.NewScanner(file)
+	var logical strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasSuffix(line, `\`) {
+			logical.WriteString(strings.TrimSuffix(line, `\`) + " ")
+			continue
+		}
+		logical.WriteString(line)
+		line = stripRequirementComment(logical.String())
+		logical.Reset()
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "-") {
+			opt, arg := splitRequirementOption(line)
+			switch opt {
+			case "-r", "--requirement", "-c", "--constraint":
+				included := arg
+				if !filepath.IsAbs(included) {
+					included = filepath.Join(filepath.Dir(path), included)
+				}
+				isConstraint := constraint || opt == "-c" || opt == "--constraint"
+				if err := p.parseFile(included, isConstraint); err != nil {
+					return err
+				}
+			case "-i", "--index-url":
+				index = arg
+			}
+			continue
+		}
+
+		dep, ok := parseRequirement(line)
+		if !ok {
+			continue
+		}
+		if constraint {
+			if dep.Version != "" {
+				p.constraints[NormalizePythonName(dep.Name)] = dep.Version
+			}
+			continue
+		}
+		dep.ManifestPath = path
+		dep.Registry = index
+		p.deps = append(p.deps, dep)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to scan %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// parseRequirement parses a PEP 508 requirement line such as
+// `requests[security]>=2.8.1,==2.8.* ; python_version < "2.7" --hash=sha256:abc`.
+func parseRequirement(line string) (model.Dependency, bool) {
+	hashes := []string{}
+	for _, m := range hashOptionRe.FindAllStringSubmatch(" "+line, -1) {
+		hashes = append(hashes, m[1])
+	}
+	if idx := strings.Index(line, " --"); idx >= 0 {
+		line = line[:idx]
+	}
+
+	line = strings.TrimSpace(line)
+	if isRequirementLocation(line) {
+		// VCS, URL and local path requirements are not resolved from an index.
+		return model.Dependency{}, false
+	}
+	m := requirementRe.FindStringSubmatch(line)
+	if m == nil {
+		return model.Dependency{}, false
+	}
+	spec, markers, _ := strings.Cut(m[3], ";")
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		// Direct URL reference, not resolved from an index.
+		return model.Dependency{}, false
+	}
+	spec = strings.TrimSuffix(strings.TrimPrefix(spec, "("), ")")
+
+	dep := model.Dependency{
+		Ecosystem: "pip",
+		Name:      m[1],
+		Direct:    true,
+		Scope:     model.ScopeProd,
+		Markers:   strings.TrimSpace(markers),
+		Integrity: strings.Join(hashes, " "),
+	}
+	for _, extra := range strings.Split(m[2], ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			dep.Extras = append(dep.Extras, extra)
+		}
+	}
+	clauses := []string{}
+	for _, clause := range strings.Split(spec, ",") {
+		sm := specifierRe.FindStringSubmatch(strings.TrimSpace(clause))
+		if sm == nil {
+			continue
+		}
+		clauses = append(clauses, sm[1]+sm[2])
+		if (sm[1] == "==" || sm[1] == "===") && !strings.Contains(sm[2], "*") {
+			dep.Version = sm[2]
+		}
+	}
+	dep.Range = strings.Join(clauses, ",")
+	return dep, true
+}
+
+// isRequirementLocation reports whether a requirement line names a VCS
+// checkout, URL or local path instead of a package, e.g.
+// git+https://github.com/org/pkg.git#egg=pkg, https://host/pkg.whl or ./pkg.
+func isRequirementLocation(line string) bool {
+	for _, prefix := range []string{"git+", "hg+", "svn+", "bzr+", "file:", "./", "../", "/", "~"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	if line == "." || line == ".." {
+		return true
+	}
+	// A scheme before any specifier or marker, as in https://host/pkg.whl.
+	if idx := strings.Index(line, "://"); idx >= 0 && !strings.ContainsAny(line[:idx], " ;=<>!~@[") {
+		return true
+	}
+	return false
+}
+
+// stripRequirementComment removes a trailing comment. A "#" only starts a
+// comment at the beginning of a line or after whitespace.
+func stripRequirementComment(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return ""
+	}
+	if idx := strings.Index(line, " #"); idx >= 0 {
+		line = line[:idx]
+	}
+	if idx := strings.Index(line, "\t#"); idx >= 0 {
+		line = line[:idx]
+	}
+	return strings.TrimSpace(line)
+}
+
+// splitRequirementOption splits "-r file", "-rfile" and "--requirement=file".
+func splitRequirementOption(line string) (string, string) {
+	if strings.HasPrefix(line, "--") {
+		if opt, arg, ok := strings.Cut(line, "="); ok && !strings.ContainsAny(opt, " \t") {
+			return opt, strings.TrimSpace(arg)
+		}
+		opt, arg, _ := strings.Cut(line, " ")
+		return opt, strings.TrimSpace(arg)
+	}
+	if len(line) > 2 && line[2] != ' ' && line[2] != '\t' {
+		return line[:2], strings.TrimSpace(line[2:])
+	}
+	opt, arg, _ := strings.Cut(line, " ")
+	return opt, strings.TrimSpace(arg)
+}
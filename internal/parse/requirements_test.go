@@ -0,0 +1,105 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParseRequirementsFile_Valid(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"requirements.txt": `# production requirements
+--index-url https://pypi.example.com/simple
+-r base.txt
+-c constraints.txt
+
+Django==4.2.7 \
+    --hash=sha256:aaa \
+    --hash=sha256:bbb
+requests[security,socks]~=2.31  # HTTP client
+urllib3>=1.26,<3 ; python_version >= "3.8"
+-e git+https://github.com/example/local.git#egg=local
+pip @ https://github.com/pypa/pip/archive/22.0.2.zip
+`,
+		"base.txt":        "attrs===23.1.0\n",
+		"constraints.txt": "urllib3==2.0.7\nnot-required==1.0\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	deps, err := ParseRequirementsFile(filepath.Join(dir, "requirements.txt"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if len(deps) != 4 {
+		t.Fatalf("expected 4 dependencies, got %+v", deps)
+	}
+	if attrs := byName["attrs"]; attrs.Version != "23.1.0" || attrs.ManifestPath != filepath.Join(dir, "base.txt") {
+		t.Errorf("unexpected attrs: %+v", attrs)
+	}
+	django := byName["Django"]
+	if django.Version != "4.2.7" || django.Integrity != "sha256:aaa sha256:bbb" || django.Registry != "https://pypi.example.com/simple" {
+		t.Errorf("unexpected Django: %+v", django)
+	}
+	requests := byName["requests"]
+	if requests.Version != "" || requests.Range != "~=2.31" || len(requests.Extras) != 2 || requests.Extras[1] != "socks" {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+	urllib3 := byName["urllib3"]
+	if urllib3.Version != "2.0.7" || urllib3.Range != ">=1.26,<3" || urllib3.Markers != `python_version >= "3.8"` {
+		t.Errorf("unexpected urllib3: %+v", urllib3)
+	}
+}
+
+func TestParseRequirementsFile_MissingFile(t *testing.T) {
+	_, err := ParseRequirementsFile("nonexistent.txt")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
+
+func TestParseRequirementsFile_MissingInclude(t *testing.T) {
+	_, err := ParseRequirementsFile(writeTempLock(t, "requirements.txt", "-r missing.txt\n"))
+	if err == nil {
+		t.Error("expected error for missing include, got nil")
+	}
+}
+
+func TestParseRequirementsFile_Locations(t *testing.T) {
+	content := `git+https://github.com/org/pkg.git#egg=pkg
+hg+https://hg.example.com/pkg#egg=hgpkg
+svn+svn://svn.example.com/pkg/trunk#egg=svnpkg
+bzr+lp:pkg#egg=bzrpkg
+https://files.example.com/pkg-1.0-py3-none-any.whl
+file:///opt/wheels/local-1.0.tar.gz
+./vendor/localpkg
+../sibling
+/opt/src/abspkg
+pkg-at @ git+https://github.com/org/pkg-at.git
+requests==2.31.0 ; python_version >= "3.8"
+`
+	dir := writeProject(t, map[string]string{"requirements.txt": content})
+	deps, err := ParseRequirementsFile(filepath.Join(dir, "requirements.txt"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := versions(deps); len(got) != 1 || got["requests"] != "2.31.0" {
+		t.Errorf("expected only requests to be read, got %v", got)
+	}
+}
+
+func TestNormalizePythonName(t *testing.T) {
+	if got := NormalizePythonName("Foo.Bar__baz"); got != "foo-bar-baz" {
+		t.Errorf("unexpected normalized name %q", got)
+	}
+}
@@ -0,0 +1,65 @@
+package parse
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+// UvPackage is a [[package]] entry of uv.lock.
+type UvPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Registry  string `toml:"registry"`
+		Editable  string `toml:"editable"`
+		Virtual   string `toml:"virtual"`
+		Directory string `toml:"directory"`
+		Path      string `toml:"path"`
+		Git       string `toml:"git"`
+		URL       string `toml:"url"`
+	} `toml:"source"`
+	Sdist struct {
+		Hash string `toml:"hash"`
+	} `toml:"sdist"`
+}
+
+type UvLockFile struct {
+	Version  int         `toml:"version"`
+	Packages []UvPackage `toml:"package"`
+}
+
+// ParseUvLockFile parses a uv.lock file and returns the registry packages it locks.
+// Declared ranges, dependency groups and extras come from the pyproject.toml next to it.
+// Workspace members and git, path or URL packages are skipped.
+func ParseUvLockFile(path string) ([]model.Dependency, error) {
+	var lock UvLockFile
+	if _, err := toml.DecodeFile(path, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse uv.lock: %w", err)
+	}
+	declared, err := parsePyProjectIfExists(filepath.Join(filepath.Dir(path), "pyproject.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	deps := []model.Dependency{}
+	for _, pkg := range lock.Packages {
+		if pkg.Source.Registry == "" {
+			continue
+		}
+		dep := model.Dependency{
+			Ecosystem:    "uv",
+			Name:         pkg.Name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Version:      pkg.Version,
+			Integrity:    pkg.Sdist.Hash,
+			Registry:     pkg.Source.Registry,
+		}
+		applyPyProjectRequirement(&dep, declared)
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+)
+
+func TestParseUvLockFile_Valid(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"pyproject.toml": `[project]
+name = "service"
+dependencies = ["httpx>=0.25"]
+
+[project.optional-dependencies]
+cli = ["rich>=13"]
+
+[dependency-groups]
+dev = ["pytest>=7", {include-group = "lint"}]
+lint = ["ruff"]
+`,
+		"uv.lock": `version = 1
+requires-python = ">=3.11"
+
+[[package]]
+name = "service"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [{ name = "httpx" }]
+
+[[package]]
+name = "httpx"
+version = "0.25.2"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.example/httpx-0.25.2.tar.gz", hash = "sha256:httpx", size = 1 }
+
+[[package]]
+name = "anyio"
+version = "4.1.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "rich"
+version = "13.7.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "7.4.3"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "ruff"
+version = "0.1.6"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "forked"
+version = "1.0.0"
+source = { git = "https://github.com/example/forked?rev=abc#abc" }
+`,
+	})
+	deps, err := ParseUvLockFile(filepath.Join(dir, "uv.lock"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 5 {
+		t.Fatalf("expected 5 dependencies, got %+v", deps)
+	}
+	byName := make(map[string]model.Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	if httpx := byName["httpx"]; !httpx.Direct || httpx.Range != ">=0.25" || httpx.Integrity != "sha256:httpx" || httpx.Registry != "https://pypi.org/simple" {
+		t.Errorf("unexpected httpx: %+v", httpx)
+	}
+	if anyio := byName["anyio"]; anyio.Direct {
+		t.Errorf("unexpected anyio: %+v", anyio)
+	}
+	if rich := byName["rich"]; !rich.Direct || !rich.Optional || rich.Scope != model.ScopeProd {
+		t.Errorf("unexpected rich: %+v", rich)
+	}
+	if pytest := byName["pytest"]; !pytest.Direct || pytest.Scope != model.ScopeDev || !containsString(pytest.Groups, "dev") {
+		t.Errorf("unexpected pytest: %+v", pytest)
+	}
+	if ruff := byName["ruff"]; !ruff.Direct || !containsString(ruff.Groups, "lint") {
+		t.Errorf("unexpected ruff: %+v", ruff)
+	}
+}
+
+func TestParseUvLockFile_MissingFile(t *testing.T) {
+	_, err := ParseUvLockFile("nonexistent.lock")
+	if err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
@@ -3,43 +3,216 @@ package parse
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
 )
 
-// ParseYarnLockFile parses a yarn.lock file (Yarn v1/classic) and returns a map of dependency names to their versions.
-func ParseYarnLockFile(path string) (map[string]string, error) {
+// YarnDescriptor is a single "name@range" key of a yarn.lock entry.
+type YarnDescriptor struct {
+	Name  string // package name as written, e.g. "@babel/core" or an alias
+	Range string // requested range, e.g. "^7.0.0" or "npm:string-width@^4.2.0"
+}
+
+// Package returns the registry package name and range the descriptor resolves,
+// following "npm:" aliases.
+func (d YarnDescriptor) Package() (name, rng string) {
+	if rest, ok := strings.CutPrefix(d.Range, "npm:"); ok {
+		if at := strings.LastIndex(rest, "@"); at > 0 {
+			return rest[:at], rest[at+1:]
+		}
+		return rest, ""
+	}
+	return d.Name, d.Range
+}
+
+// YarnLockEntry is one block of a Yarn v1 (classic) lock file. All descriptors of
+// an entry resolve to the same version.
+type YarnLockEntry struct {
+	Descriptors          []YarnDescriptor
+	Version              string
+	Resolved             string
+	Integrity            string
+	Dependencies         map[string]string
+	OptionalDependencies map[string]string
+}
+
+// ParseYarnLockFile parses a yarn.lock file (Yarn v1/classic) and returns the dependencies it locks.
+// Every resolved version of a package is returned as its own dependency; the
+// ones declared in the package.json next to the lock file are marked direct.
+func ParseYarnLockFile(path string) ([]model.Dependency, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open yarn.lock: %w", err)
 	}
 	defer file.Close()
 
-	deps := make(map[string]string)
-	scanner := bufio.NewScanner(file)
-	var currentDep string
-	versionRe := regexp.MustCompile(`^  version "([^"]+)"`)
-	depRe := regexp.MustCompile(`^([^"]+)@.*:`)
+	entries, err := ParseYarnLockEntries(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan yarn.lock: %w", err)
+	}
+
+	deps := []model.Dependency{}
+	for _, entry := range entries {
+		// One entry may hold descriptors of several names when aliases are involved.
+		ranges := make(map[string][]string)
+		names := []string{}
+		for _, desc := range entry.Descriptors {
+			name, rng := desc.Package()
+			if _, ok := ranges[name]; !ok {
+				names = append(names, name)
+			}
+			ranges[name] = append(ranges[name], rng)
+		}
+		for _, name := range names {
+			deps = append(deps, model.Dependency{
+				Ecosystem:    "yarn",
+				Name:         name,
+				ManifestPath: path,
+				Scope:        model.ScopeProd,
+				Range:        strings.Join(ranges[name], " || "),
+				Version:      entry.Version,
+				Integrity:    entry.Integrity,
+				Registry:     entry.Resolved,
+			})
+		}
+	}
+	if err := markPackageJSONDirect(path, deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// ParseYarnLockEntries reads a Yarn v1 lock file. Entries without a valid
+// version are skipped.
+func ParseYarnLockEntries(r io.Reader) ([]YarnLockEntry, error) {
+	entries := []YarnLockEntry{}
+	var current *YarnLockEntry
+	var block map[string]string
+	flush := func() {
+		if current != nil && current.Version != "" {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
 
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if depRe.MatchString(line) {
-			// e.g. "lodash@^4.17.20:", "react@^17.0.0, react@^17.0.1:"
-			depLine := strings.Split(line, ":")[0]
-			depNames := strings.Split(depLine, ",")
-			// Only take the first dep name (main package)
-			currentDep = strings.TrimSpace(strings.Split(depNames[0], "@")[0])
-		} else if versionRe.MatchString(line) && currentDep != "" {
-			matches := versionRe.FindStringSubmatch(line)
-			if len(matches) == 2 {
-				deps[currentDep] = matches[1]
-				currentDep = ""
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+
+		switch {
+		case indent == 0:
+			// e.g. lodash@^4.17.20: or "@babel/core@^7.0.0", "@babel/core@^7.12.3":
+			flush()
+			if !strings.HasSuffix(trimmed, ":") {
+				continue
+			}
+			current = &YarnLockEntry{}
+			block = nil
+			for _, raw := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				if desc, ok := parseYarnDescriptor(raw); ok {
+					current.Descriptors = append(current.Descriptors, desc)
+				}
+			}
+		case current == nil:
+			continue
+		case indent == 2:
+			block = nil
+			if key, ok := strings.CutSuffix(trimmed, ":"); ok {
+				switch key {
+				case "dependencies":
+					current.Dependencies = make(map[string]string)
+					block = current.Dependencies
+				case "optionalDependencies":
+					current.OptionalDependencies = make(map[string]string)
+					block = current.OptionalDependencies
+				}
+				continue
+			}
+			key, value, ok := splitYarnField(trimmed)
+			if !ok {
+				continue
+			}
+			switch key {
+			case "version":
+				current.Version = value
+			case "resolved":
+				current.Resolved = value
+			case "integrity":
+				current.Integrity = value
+			}
+		case block != nil:
+			if key, value, ok := splitYarnField(trimmed); ok {
+				block[key] = value
 			}
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to scan yarn.lock: %w", err)
+		return nil, err
 	}
-	return deps, nil
+	flush()
+	return entries, nil
+}
+
+// parseYarnDescriptor splits a possibly quoted "name@range" descriptor. The name
+// of scoped packages starts with "@", so the separator is searched after it.
+func parseYarnDescriptor(raw string) (YarnDescriptor, bool) {
+	s, ok := yarnString(strings.TrimSpace(raw))
+	if !ok || s == "" {
+		return YarnDescriptor{}, false
+	}
+	at := strings.Index(s[1:], "@")
+	if at < 0 {
+		return YarnDescriptor{Name: s}, true
+	}
+	return YarnDescriptor{Name: s[:at+1], Range: s[at+2:]}, true
+}
+
+// splitYarnField splits a `key value` line where either part may be quoted.
+func splitYarnField(line string) (string, string, bool) {
+	var rawKey, rawValue string
+	if strings.HasPrefix(line, `"`) {
+		end := strings.Index(line[1:], `"`)
+		if end < 0 {
+			return "", "", false
+		}
+		rawKey, rawValue = line[:end+2], strings.TrimSpace(line[end+2:])
+	} else {
+		var ok bool
+		rawKey, rawValue, ok = strings.Cut(line, " ")
+		if !ok {
+			return "", "", false
+		}
+	}
+	key, ok := yarnString(rawKey)
+	if !ok {
+		return "", "", false
+	}
+	value, ok := yarnString(strings.TrimSpace(rawValue))
+	if !ok {
+		return "", "", false
+	}
+	return key, value, true
+}
+
+// yarnString decodes a lock file string. Yarn quotes every string that starts
+// with a digit, so an unquoted value like 4.17.21 is not a valid string.
+func yarnString(s string) (string, bool) {
+	if strings.HasPrefix(s, `"`) {
+		v, err := strconv.Unquote(s)
+		return v, err == nil
+	}
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return "", false
+	}
+	return s, true
 }
@@ -0,0 +1,126 @@
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cyber-kamil/depflow/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// YarnBerryEntry is one package of a Yarn 2+ (Berry) lock file.
+type YarnBerryEntry struct {
+	Version          string            `yaml:"version"`
+	Resolution       string            `yaml:"resolution"`
+	Checksum         string            `yaml:"checksum"`
+	LanguageName     string            `yaml:"languageName"`
+	LinkType         string            `yaml:"linkType"`
+	Dependencies     map[string]string `yaml:"dependencies"`
+	PeerDependencies map[string]string `yaml:"peerDependencies"`
+}
+
+// IsYarnBerryLockFile reports whether the yarn.lock at path was written by Yarn 2 or newer.
+// Berry lock files are YAML documents with a top-level __metadata key.
+func IsYarnBerryLockFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open yarn.lock: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if strings.TrimRight(scanner.Text(), " \r") == "__metadata:" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to scan yarn.lock: %w", err)
+	}
+	return false, nil
+}
+
+// ParseYarnBerryLockFile parses a Yarn 2+ yarn.lock file and returns the npm packages it locks.
+// Workspace, patch, portal, link and other non-registry resolutions are skipped.
+// Dependencies declared in the package.json next to the lock file are marked direct.
+func ParseYarnBerryLockFile(path string) ([]model.Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yarn.lock: %w", err)
+	}
+	var lock map[string]YarnBerryEntry
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse yarn.lock: %w", err)
+	}
+
+	deps := []model.Dependency{}
+	for key, entry := range lock {
+		if key == "__metadata" {
+			continue
+		}
+		name, version, ok := splitBerryLocator(entry.Resolution)
+		if !ok {
+			continue
+		}
+		if entry.Version != "" {
+			version = entry.Version
+		}
+		ranges := []string{}
+		for _, raw := range strings.Split(key, ",") {
+			if rng, ok := berryDescriptorRange(strings.TrimSpace(raw)); ok {
+				ranges = append(ranges, rng)
+			}
+		}
+		deps = append(deps, model.Dependency{
+			Ecosystem:    "yarn",
+			Name:         name,
+			ManifestPath: path,
+			Scope:        model.ScopeProd,
+			Range:        strings.Join(ranges, " || "),
+			Version:      version,
+			Integrity:    entry.Checksum,
+		})
+	}
+	if err := markPackageJSONDirect(path, deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// splitBerryLocator splits an npm locator such as "@babel/core@npm:7.22.5".
+// Locators using any other protocol (workspace:, patch:, portal:, ...) are rejected.
+func splitBerryLocator(locator string) (name, version string, ok bool) {
+	if locator == "" {
+		return "", "", false
+	}
+	at := strings.Index(locator[1:], "@")
+	if at < 0 {
+		return "", "", false
+	}
+	name, ref := locator[:at+1], locator[at+2:]
+	version, ok = strings.CutPrefix(ref, "npm:")
+	return name, version, ok
+}
+
+// berryDescriptorRange returns the semver range of an npm descriptor such as
+// "debug@npm:^4.1.0" or the aliased "string-width-cjs@npm:string-width@^4.2.0".
+func berryDescriptorRange(descriptor string) (string, bool) {
+	if descriptor == "" {
+		return "", false
+	}
+	at := strings.Index(descriptor[1:], "@")
+	if at < 0 {
+		return "", false
+	}
+	rng, ok := strings.CutPrefix(descriptor[at+2:], "npm:")
+	if !ok {
+		return "", false
+	}
+	if alias := strings.LastIndex(rng, "@"); alias > 0 {
+		rng = rng[alias+1:]
+	}
+	return rng, true
+}
@@ -0,0 +1,100 @@
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const berryLock = `# This file is generated by running "yarn install" inside your project.
+# Manual changes might be lost - proceed with caution!
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@babel/core@npm:^7.0.0, @babel/core@npm:^7.12.3":
+  version: 7.22.5
+  resolution: "@babel/core@npm:7.22.5"
+  dependencies:
+    debug: "npm:^4.1.0"
+  checksum: 10c0/abc
+  languageName: node
+  linkType: hard
+
+"debug@npm:^4.1.0":
+  version: 4.3.4
+  resolution: "debug@npm:4.3.4"
+  checksum: 10c0/def
+  languageName: node
+  linkType: hard
+
+"my-app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "my-app@workspace:."
+  languageName: unknown
+  linkType: soft
+
+"resolve@patch:resolve@npm%3A^1.20.0#~builtin<compat/resolve>":
+  version: 1.22.2
+  resolution: "resolve@patch:resolve@npm%3A1.22.2#~builtin<compat/resolve>::version=1.22.2&hash=c3c19d"
+  languageName: node
+  linkType: hard
+
+"shared@portal:../shared::locator=my-app%40workspace%3A.":
+  version: 0.0.0-use.local
+  resolution: "shared@portal:../shared::locator=my-app%40workspace%3A."
+  languageName: node
+  linkType: soft
+`
+
+func writeTempLock(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestIsYarnBerryLockFile(t *testing.T) {
+	berry := writeTempLock(t, "yarn.lock", berryLock)
+	classic := writeTempLock(t, "yarn.lock", "# yarn lockfile v1\n\nlodash@^4.17.20:\n  version \"4.17.21\"\n")
+	if ok, err := IsYarnBerryLockFile(berry); err != nil || !ok {
+		t.Errorf("expected berry lock file, got %v (err %v)", ok, err)
+	}
+	if ok, err := IsYarnBerryLockFile(classic); err != nil || ok {
+		t.Errorf("expected classic lock file, got %v (err %v)", ok, err)
+	}
+}
+
+func TestParseYarnBerryLockFile_Valid(t *testing.T) {
+	deps, err := ParseYarnBerryLockFile(writeTempLock(t, "yarn.lock", berryLock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Fatalf("expected only npm packages, got %+v", deps)
+	}
+	for _, d := range deps {
+		switch d.Name {
+		case "@babel/core":
+			if d.Version != "7.22.5" || d.Range != "^7.0.0 || ^7.12.3" || d.Integrity != "10c0/abc" {
+				t.Errorf("unexpected @babel/core: %+v", d)
+			}
+		case "debug":
+			if d.Version != "4.3.4" || d.Range != "^4.1.0" {
+				t.Errorf("unexpected debug: %+v", d)
+			}
+		default:
+			t.Errorf("unexpected dependency %s", d.Name)
+		}
+	}
+}
+
+func TestParseYarnBerryLockFile_Malformed(t *testing.T) {
+	_, err := ParseYarnBerryLockFile(writeTempLock(t, "yarn.lock", "__metadata:\n  version: [8\n"))
+	if err == nil {
+		t.Error("expected error for malformed file, got nil")
+	}
+}
@@ -2,6 +2,8 @@ package parse
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -25,15 +27,81 @@ express@^4.18.2:
 	}
 	tmp.Close()
 
-	deps, err := ParseYarnLockFile(tmp.Name())
+	list, err := ParseYarnLockFile(tmp.Name())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	deps := versions(list)
 	if deps["lodash"] != "4.17.21" || deps["express"] != "4.18.2" {
 		t.Errorf("unexpected deps: %v", deps)
 	}
 }
 
+func TestParseYarnLockFile_ScopedAndMultipleVersions(t *testing.T) {
+	lock := `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/core@^7.0.0", "@babel/core@^7.12.3":
+  version "7.22.5"
+  resolved "https://registry.yarnpkg.com/@babel/core/-/core-7.22.5.tgz#abc"
+  integrity sha512-babel
+  dependencies:
+    "@ampproject/remapping" "^2.2.0"
+    debug "^4.1.0"
+
+debug@^3.2.7:
+  version "3.2.7"
+
+debug@^4.1.0:
+  version "4.3.4"
+  optionalDependencies:
+    supports-color "^8.0.0"
+
+"string-width-cjs@npm:string-width@^4.2.0", string-width@^4.1.0:
+  version "4.2.3"
+`
+	entries, err := ParseYarnLockEntries(strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+	babel := entries[0]
+	if len(babel.Descriptors) != 2 || babel.Descriptors[0].Name != "@babel/core" || babel.Descriptors[1].Range != "^7.12.3" {
+		t.Errorf("unexpected descriptors: %+v", babel.Descriptors)
+	}
+	if babel.Integrity != "sha512-babel" || babel.Dependencies["@ampproject/remapping"] != "^2.2.0" || babel.Dependencies["debug"] != "^4.1.0" {
+		t.Errorf("unexpected babel entry: %+v", babel)
+	}
+	if entries[2].OptionalDependencies["supports-color"] != "^8.0.0" {
+		t.Errorf("unexpected optional dependencies: %+v", entries[2])
+	}
+
+	path := filepath.Join(t.TempDir(), "yarn.lock")
+	if err := os.WriteFile(path, []byte(lock), 0644); err != nil {
+		t.Fatalf("failed to write yarn.lock: %v", err)
+	}
+	deps, err := ParseYarnLockFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := []string{}
+	for _, d := range deps {
+		got = append(got, d.Name+"@"+d.Version+" ("+d.Range+")")
+	}
+	want := []string{
+		"@babel/core@7.22.5 (^7.0.0 || ^7.12.3)",
+		"debug@3.2.7 (^3.2.7)",
+		"debug@4.3.4 (^4.1.0)",
+		"string-width@4.2.3 (^4.2.0 || ^4.1.0)",
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("unexpected dependencies:\n got %v\nwant %v", got, want)
+	}
+}
+
 func TestParseYarnLockFile_MissingFile(t *testing.T) {
 	_, err := ParseYarnLockFile("nonexistent.lock")
 	if err == nil {
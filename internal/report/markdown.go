@@ -2,23 +2,35 @@ package report
 
 import "github.com/cyber-kamil/depflow/internal/model"
 
-type NpmDepReport struct {
-	Name     string
-	Current  string
-	Latest   string
-	Outdated bool
-}
-
-// GenerateNpmMarkdownReport generates a Markdown report for npm dependencies, including changelog links and highlights if provided.
-func GenerateNpmMarkdownReport(deps []NpmDepReport, changelogs map[string]*model.ChangelogInfo) string {
-	report := "# NPM Dependency Update Report\n\n"
-	report += "| Dependency | Current Version | Latest Version | Status | Changelog | Highlights |\n"
-	report += "|------------|-----------------|---------------|--------|-----------|------------|\n"
+// GenerateMarkdownReport generates a Markdown report for dependencies, including changelog links and highlights if provided.
+// A Wanted Version column is added when any dependency has a wanted version.
+func GenerateMarkdownReport(deps []model.Dependency, changelogs map[string]*model.ChangelogInfo) string {
+	withWanted := false
+	for _, dep := range deps {
+		if dep.Wanted != "" {
+			withWanted = true
+			break
+		}
+	}
+	report := "# Dependency Update Report\n\n"
+	if withWanted {
+		report += "| Dependency | Current Version | Wanted Version | Latest Version | Update | Status | Changelog | Highlights |\n"
+		report += "|------------|-----------------|----------------|---------------|--------|--------|-----------|------------|\n"
+	} else {
+		report += "| Dependency | Current Version | Latest Version | Update | Status | Changelog | Highlights |\n"
+		report += "|------------|-----------------|---------------|--------|--------|-----------|------------|\n"
+	}
 	for _, dep := range deps {
 		status := "Up to date"
 		if dep.Outdated {
 			status = "Update available"
 		}
+		if dep.Replace != "" {
+			status += "<br>replaced → " + dep.Replace
+		}
+		for _, note := range dep.Notes {
+			status += "<br>⚠ " + note
+		}
 		changelog := ""
 		highlights := ""
 		if info, ok := changelogs[dep.Name]; ok {
@@ -32,7 +44,28 @@ func GenerateNpmMarkdownReport(deps []NpmDepReport, changelogs map[string]*model
 				}
 			}
 		}
-		report += "| " + dep.Name + " | " + dep.Current + " | " + dep.Latest + " | " + status + " | " + changelog + " | " + highlights + " |\n"
+		current := dep.Version
+		if current == "" {
+			// Unpinned requirements only have a declared range.
+			current = dep.Range
+		}
+		if dep.VersionDetail != "" {
+			current += "<br>" + dep.VersionDetail
+		}
+		if withWanted {
+			current += " | " + dep.Wanted
+		}
+		latest := dep.Latest
+		if dep.Channel != "" && latest != "" {
+			latest += " (" + dep.Channel + ")"
+		}
+		for _, channel := range dep.Channels {
+			if latest != "" {
+				latest += "<br>"
+			}
+			latest += channel
+		}
+		report += "| " + dep.Name + " | " + current + " | " + latest + " | " + dep.UpdateType + " | " + status + " | " + changelog + " | " + highlights + " |\n"
 	}
 	return report
 }
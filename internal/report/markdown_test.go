@@ -7,12 +7,12 @@ import (
 	"github.com/cyber-kamil/depflow/internal/model"
 )
 
-func TestGenerateNpmMarkdownReport(t *testing.T) {
-	deps := []NpmDepReport{
-		{Name: "express", Current: "4.18.2", Latest: "4.18.2", Outdated: false},
-		{Name: "lodash", Current: "4.17.20", Latest: "4.17.21", Outdated: true},
+func TestGenerateMarkdownReport(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "express", Version: "4.18.2", Latest: "4.18.2", Outdated: false},
+		{Name: "lodash", Version: "4.17.20", Latest: "4.17.21", Outdated: true},
 	}
-	report := GenerateNpmMarkdownReport(deps, map[string]*model.ChangelogInfo{})
+	report := GenerateMarkdownReport(deps, map[string]*model.ChangelogInfo{})
 	if !strings.Contains(report, "express") || !strings.Contains(report, "lodash") {
 		t.Error("report missing dependency names")
 	}
@@ -23,3 +23,60 @@ func TestGenerateNpmMarkdownReport(t *testing.T) {
 		t.Error("report missing up to date info")
 	}
 }
+
+func TestGenerateMarkdownReport_Notes(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "serde", Version: "1.0.194", Latest: "1.0.193", Notes: []string{"yanked from the registry"}},
+	}
+	report := GenerateMarkdownReport(deps, nil)
+	if !strings.Contains(report, "Up to date<br>⚠ yanked from the registry") {
+		t.Errorf("report missing note, got:\n%s", report)
+	}
+}
+
+func TestGenerateMarkdownReport_UpdateType(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "lodash", Version: "4.17.20", Latest: "5.0.0", Outdated: true, UpdateType: "major"},
+	}
+	report := GenerateMarkdownReport(deps, nil)
+	if !strings.Contains(report, "| Update |") || !strings.Contains(report, "| 4.17.20 | 5.0.0 | major | Update available |") {
+		t.Errorf("report missing update type, got:\n%s", report)
+	}
+}
+
+func TestGenerateMarkdownReport_Wanted(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "lodash", Range: "^4.17.0", Version: "4.17.20", Wanted: "4.17.21", Latest: "5.0.0", Outdated: true, UpdateType: "major"},
+		{Name: "left-pad", Version: "1.3.0", Latest: "1.3.0"},
+	}
+	report := GenerateMarkdownReport(deps, nil)
+	if !strings.Contains(report, "| Current Version | Wanted Version | Latest Version |") {
+		t.Errorf("report missing wanted column, got:\n%s", report)
+	}
+	if !strings.Contains(report, "| lodash | 4.17.20 | 4.17.21 | 5.0.0 | major |") || !strings.Contains(report, "| left-pad | 1.3.0 |  | 1.3.0 |") {
+		t.Errorf("report missing wanted versions, got:\n%s", report)
+	}
+	if strings.Contains(GenerateMarkdownReport(deps[1:], nil), "Wanted") {
+		t.Error("wanted column shown without wanted versions")
+	}
+}
+
+func TestGenerateMarkdownReport_VersionDetail(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "example.com/mod", Version: "v0.0.0-20240101120000-abcdef123456", VersionDetail: "committed 2024-01-01"},
+	}
+	report := GenerateMarkdownReport(deps, nil)
+	if !strings.Contains(report, "| v0.0.0-20240101120000-abcdef123456<br>committed 2024-01-01 |") {
+		t.Errorf("report missing version detail, got:\n%s", report)
+	}
+}
+
+func TestGenerateMarkdownReport_Channels(t *testing.T) {
+	deps := []model.Dependency{
+		{Name: "typescript", Version: "5.3.3", Latest: "5.4.0-beta", Channel: "beta", Channels: []string{"latest: 5.3.4", "next: 5.5.0-dev.20240301"}},
+	}
+	report := GenerateMarkdownReport(deps, nil)
+	if !strings.Contains(report, "| 5.3.3 | 5.4.0-beta (beta)<br>latest: 5.3.4<br>next: 5.5.0-dev.20240301 |") {
+		t.Errorf("report missing release channels, got:\n%s", report)
+	}
+}
@@ -3,21 +3,18 @@ package scan
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/cyber-kamil/depflow/internal/ecosystem"
 )
 
-var SupportedLockFiles = []string{
-	"go.mod",
-	"requirements.txt",
-	"Pipfile.lock",
-	"pom.xml",
-	"build.gradle",
-	"package-lock.json",
-	"yarn.lock",
+// SupportedLockFiles returns the lock file names of every registered ecosystem.
+func SupportedLockFiles() []string {
+	return ecosystem.LockFiles()
 }
 
 func ScanForLockFiles(dir string) ([]string, error) {
 	found := []string{}
-	for _, lf := range SupportedLockFiles {
+	for _, lf := range SupportedLockFiles() {
 		path := filepath.Join(dir, lf)
 		if _, err := os.Stat(path); err == nil {
 			found = append(found, lf)
@@ -25,3 +22,25 @@ func ScanForLockFiles(dir string) ([]string, error) {
 	}
 	return found, nil
 }
+
+// Detection is a lock file found in a scanned directory together with the
+// ecosystem that handles it.
+type Detection struct {
+	Ecosystem ecosystem.Ecosystem
+	Path      string
+}
+
+// Detect asks every registered ecosystem for its lock file in dir.
+func Detect(dir string) ([]Detection, error) {
+	detections := []Detection{}
+	for _, e := range ecosystem.All() {
+		path, err := e.Detect(dir)
+		if err != nil {
+			return nil, err
+		}
+		if path != "" {
+			detections = append(detections, Detection{Ecosystem: e, Path: path})
+		}
+	}
+	return detections, nil
+}
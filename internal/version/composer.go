@@ -0,0 +1,68 @@
+package version
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// Composer orders Composer package versions such as "v2.1.0", "1.2.0.0-beta2"
+// or "3.0.0-RC1": up to four numeric segments, then the stability suffix
+// (dev < alpha < beta < RC < stable < patch) and its number. Branches such as
+// "dev-main" cannot be ordered.
+var Composer Scheme = composerVersion{}
+
+type composerVersion struct{}
+
+func parseComposer(v string) (release []int, suffix string, ok bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" || strings.HasPrefix(v, "dev-") {
+		return nil, "", false
+	}
+	base, suffix, _ := strings.Cut(v, "-")
+	release, ok = numericSegments(strings.Split(base, "."))
+	return release, suffix, ok && len(release) <= 4
+}
+
+// composerSuffixRank orders release suffixes: dev < alpha < beta < RC < none < patch.
+func composerSuffixRank(suffix string) int {
+	suffix = strings.ToLower(suffix)
+	switch {
+	case strings.HasPrefix(suffix, "dev"):
+		return 0
+	case strings.HasPrefix(suffix, "a"):
+		return 1
+	case strings.HasPrefix(suffix, "b"):
+		return 2
+	case strings.HasPrefix(suffix, "rc"):
+		return 3
+	case suffix == "":
+		return 4
+	}
+	return 5 // patch, pl, p
+}
+
+func composerSuffixNumber(suffix string) int {
+	n, _ := strconv.Atoi(strings.TrimLeft(strings.ToLower(suffix), "abcdefghijklmnopqrstuvwxyz.-"))
+	return n
+}
+
+func (composerVersion) Compare(a, b string) (int, bool) {
+	releaseA, suffixA, okA := parseComposer(a)
+	releaseB, suffixB, okB := parseComposer(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if c := compareSegments(releaseA, releaseB); c != 0 {
+		return c, true
+	}
+	if c := cmp.Compare(composerSuffixRank(suffixA), composerSuffixRank(suffixB)); c != 0 {
+		return c, true
+	}
+	return cmp.Compare(composerSuffixNumber(suffixA), composerSuffixNumber(suffixB)), true
+}
+
+func (composerVersion) Release(v string) ([]int, bool) {
+	release, _, ok := parseComposer(v)
+	return release, ok
+}
@@ -0,0 +1,17 @@
+package version
+
+import "testing"
+
+func TestComposerCompare(t *testing.T) {
+	testCompare(t, Composer, []compareCase{
+		{"1.10.0.0", "1.9.0.0", 1},
+		{"v2.0.0", "2.0.0.0", 0},
+		{"2.0.0.0-beta2", "2.0.0.0-beta10", -1},
+		{"2.0.0.0-RC1", "2.0.0.0", -1},
+		{"2.0.0.0-alpha1", "2.0.0.0-beta1", -1},
+		{"2.0.0.0-patch1", "2.0.0.0", 1},
+	})
+	if _, ok := Composer.Compare("dev-main", "1.0.0"); ok {
+		t.Error("expected branches not to be ordered")
+	}
+}
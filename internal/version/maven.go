@@ -0,0 +1,179 @@
+package version
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Maven orders Maven artifact versions like Maven's ComparableVersion:
+// numbers compare numerically and well-known qualifiers in the order
+// alpha < beta < milestone < rc < snapshot < release < sp.
+var Maven Scheme = mavenVersion{}
+
+type mavenVersion struct{}
+
+// mavenQualifiers ranks known qualifiers; the empty qualifier is a release.
+var mavenQualifiers = map[string]int{
+	"alpha": 0, "a": 0,
+	"beta": 1, "b": 1,
+	"milestone": 2, "m": 2,
+	"rc": 3, "cr": 3,
+	"snapshot": 4,
+	"":         5, "ga": 5, "final": 5, "release": 5,
+	"sp": 6,
+}
+
+type mavenItem struct {
+	number    int
+	qualifier string
+	isNumber  bool
+}
+
+// parseMaven splits a version at dots, hyphens and digit/letter transitions.
+func parseMaven(v string) ([]mavenItem, bool) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return nil, false
+	}
+	items := []mavenItem{}
+	start := 0
+	flush := func(end int) {
+		if end <= start {
+			return
+		}
+		token := v[start:end]
+		if n, err := strconv.Atoi(token); err == nil {
+			items = append(items, mavenItem{number: n, isNumber: true})
+		} else {
+			items = append(items, mavenItem{qualifier: token})
+		}
+	}
+	for i, r := range v {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(v[i-1])):
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(v))
+	// Trailing zeros and release qualifiers do not change the version: 1.0 == 1.
+	for len(items) > 1 {
+		last := items[len(items)-1]
+		if (last.isNumber && last.number == 0) || (!last.isNumber && mavenQualifiers[last.qualifier] == 5 && isKnownMavenQualifier(last.qualifier)) {
+			items = items[:len(items)-1]
+			continue
+		}
+		break
+	}
+	return items, len(items) > 0
+}
+
+func isKnownMavenQualifier(q string) bool {
+	_, ok := mavenQualifiers[q]
+	return ok
+}
+
+// compareMavenItems compares two items; a missing item is a zero or a release.
+func compareMavenItems(a, b *mavenItem) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -compareMavenItems(b, nil)
+	case a.isNumber:
+		if b == nil {
+			return cmp.Compare(a.number, 0)
+		}
+		if b.isNumber {
+			return cmp.Compare(a.number, b.number)
+		}
+		return 1 // 1.0.1 > 1.0-rc1
+	case b == nil || b.isNumber:
+		if b != nil {
+			return -1
+		}
+		return compareMavenQualifiers(a.qualifier, "")
+	}
+	return compareMavenQualifiers(a.qualifier, b.qualifier)
+}
+
+// compareMavenQualifiers orders known qualifiers by rank and unknown ones
+// after all known ones, alphabetically.
+func compareMavenQualifiers(a, b string) int {
+	rankA, knownA := mavenQualifiers[a]
+	rankB, knownB := mavenQualifiers[b]
+	switch {
+	case knownA && knownB:
+		return cmp.Compare(rankA, rankB)
+	case knownA:
+		return -1
+	case knownB:
+		return 1
+	}
+	return cmp.Compare(a, b)
+}
+
+func (mavenVersion) Compare(a, b string) (int, bool) {
+	itemsA, okA := parseMaven(a)
+	itemsB, okB := parseMaven(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < len(itemsA) || i < len(itemsB); i++ {
+		var x, y *mavenItem
+		if i < len(itemsA) {
+			x = &itemsA[i]
+		}
+		if i < len(itemsB) {
+			y = &itemsB[i]
+		}
+		if c := compareMavenItems(x, y); c != 0 {
+			return c, true
+		}
+	}
+	return 0, true
+}
+
+// MavenPrerelease reports whether v carries a pre-release qualifier such as
+// alpha, beta, milestone, rc or SNAPSHOT. Unknown qualifiers like "jre" do not
+// make a version a pre-release.
+func MavenPrerelease(v string) bool {
+	items, _ := parseMaven(v)
+	for _, item := range items {
+		if rank, ok := mavenQualifiers[item.qualifier]; ok && !item.isNumber && rank < mavenQualifiers[""] {
+			return true
+		}
+	}
+	return false
+}
+
+func (mavenVersion) Release(v string) ([]int, bool) {
+	return leadingNumbers(v)
+}
+
+// leadingNumbers returns the dot-separated numbers at the start of v,
+// e.g. [1 2] for "1.2-SNAPSHOT".
+func leadingNumbers(v string) ([]int, bool) {
+	v = strings.TrimLeft(strings.TrimSpace(v), "vV")
+	segments := []int{}
+	for _, part := range strings.Split(v, ".") {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(part[:end])
+		segments = append(segments, n)
+		if end < len(part) {
+			break
+		}
+	}
+	return segments, len(segments) > 0
+}
@@ -0,0 +1,35 @@
+package version
+
+import "testing"
+
+func TestMavenCompare(t *testing.T) {
+	testCompare(t, Maven, []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1.0-alpha1", "1.0-beta1", -1},
+		{"1.0-M1", "1.0-RC1", -1},
+		{"1.0-RC1", "1.0-SNAPSHOT", -1},
+		{"1.0-SNAPSHOT", "1.0", -1},
+		{"1.0", "1.0-sp1", -1},
+		{"1.0.Final", "1.0", 0},
+		{"1.0-rc1", "1.0.1", -1},
+		{"2.10", "2.9", 1},
+		{"33.0.0-jre", "32.1.3-jre", 1},
+	})
+}
+
+func TestMavenPrerelease(t *testing.T) {
+	cases := map[string]bool{
+		"6.1.2":          false,
+		"32.1.3-jre":     false,
+		"5.3.31.RELEASE": false,
+		"7.0.0-M3":       true,
+		"7.0.0-RC1":      true,
+		"2.0.0-beta-2":   true,
+		"1.1-SNAPSHOT":   true,
+	}
+	for v, want := range cases {
+		if got := MavenPrerelease(v); got != want {
+			t.Errorf("MavenPrerelease(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// NpmRange is an npm semver range such as "^1.2.0 || >=2.1.0 <3": a union of
+// comparator sets that each have to match completely.
+type NpmRange [][]npmComparator
+
+// npmComparator compares against a full version, e.g. ">=" "v1.2.0". Without
+// a version it matches every release, or none for "<".
+type npmComparator struct {
+	op      string
+	version string
+}
+
+// ParseNpmRange parses the range syntax of node-semver: primitives (<, <=, >,
+// >=, =), caret and tilde ranges, x-ranges, hyphen ranges and || unions.
+// An empty range or "*" matches every release.
+func ParseNpmRange(s string) (NpmRange, error) {
+	var r NpmRange
+	for _, part := range strings.Split(s, "||") {
+		set, err := parseNpmComparatorSet(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid npm range %q: %w", s, err)
+		}
+		r = append(r, set)
+	}
+	return r, nil
+}
+
+// Satisfies reports whether v is in the range. As in npm, a prerelease only
+// matches a comparator set that names a prerelease of the same release.
+func (r NpmRange) Satisfies(v string) bool {
+	v = semverPrefix(v)
+	if !semver.IsValid(v) {
+		return false
+	}
+	for _, set := range r {
+		if npmSetSatisfies(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSatisfying returns the newest of versions in the range, or "" if none is.
+func (r NpmRange) MaxSatisfying(versions []string) string {
+	best := ""
+	for _, v := range versions {
+		if !r.Satisfies(v) {
+			continue
+		}
+		if best == "" || semver.Compare(semverPrefix(v), semverPrefix(best)) > 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+func npmSetSatisfies(set []npmComparator, v string) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if semver.Prerelease(v) == "" {
+		return true
+	}
+	release := npmRelease(v)
+	for _, c := range set {
+		if c.version != "" && semver.Prerelease(c.version) != "" && npmRelease(c.version) == release {
+			return true
+		}
+	}
+	return false
+}
+
+// npmRelease returns the version without prerelease and build metadata.
+func npmRelease(v string) string {
+	return strings.TrimSuffix(semver.Canonical(v), semver.Prerelease(v))
+}
+
+func (c npmComparator) matches(v string) bool {
+	if c.version == "" {
+		return c.op != "<"
+	}
+	cmp := semver.Compare(v, c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+func parseNpmComparatorSet(s string) ([]npmComparator, error) {
+	if from, to, ok := splitNpmHyphen(s); ok {
+		lo, err := parseNpmPartial(from)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parseNpmPartial(to)
+		if err != nil {
+			return nil, err
+		}
+		return append(lo.lowerBound(">="), hi.upperBound("<=")...), nil
+	}
+
+	set := []npmComparator{}
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		token := fields[i]
+		// Operators may be separated from their version: ">= 1.2.3".
+		if strings.TrimLeft(token, "<>=~^") == "" && i+1 < len(fields) {
+			i++
+			token += fields[i]
+		}
+		comparators, err := parseNpmComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	if len(set) == 0 {
+		set = append(set, npmComparator{op: ">="})
+	}
+	return set, nil
+}
+
+// splitNpmHyphen splits a hyphen range such as "1.2 - 2.3.4".
+func splitNpmHyphen(s string) (from, to string, ok bool) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 || fields[1] != "-" {
+		return "", "", false
+	}
+	return fields[0], fields[2], true
+}
+
+func parseNpmComparator(token string) ([]npmComparator, error) {
+	op := ""
+	for _, prefix := range []string{"<=", ">=", "~>", "<", ">", "=", "~", "^"} {
+		if rest, ok := strings.CutPrefix(token, prefix); ok {
+			op, token = prefix, rest
+			break
+		}
+	}
+	p, err := parseNpmPartial(token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "", "=":
+		return append(p.lowerBound(">="), p.upperBound("<=")...), nil
+	case "~", "~>":
+		return append(p.lowerBound(">="), p.tildeUpper()), nil
+	case "^":
+		return append(p.lowerBound(">="), p.caretUpper()), nil
+	case ">=":
+		return p.lowerBound(">="), nil
+	case ">":
+		return p.lowerBound(">"), nil
+	case "<=":
+		return p.upperBound("<="), nil
+	}
+	return p.upperBound("<"), nil
+}
+
+// npmPartial is a possibly incomplete version such as "1", "1.2.x" or "*".
+// parts holds the numeric segments that were given.
+type npmPartial struct {
+	parts      []int
+	prerelease string // including the leading "-"
+}
+
+func parseNpmPartial(s string) (npmPartial, error) {
+	s = strings.TrimLeft(s, "=v")
+	s, _, _ = strings.Cut(s, "+")
+	var p npmPartial
+	if core, pre, ok := strings.Cut(s, "-"); ok {
+		s, p.prerelease = core, "-"+pre
+	}
+	if s == "" {
+		return p, nil
+	}
+	for _, seg := range strings.Split(s, ".") {
+		if seg == "x" || seg == "X" || seg == "*" {
+			break
+		}
+		n, err := strconv.Atoi(seg)
+		if err != nil || n < 0 {
+			return npmPartial{}, fmt.Errorf("invalid version %q", s)
+		}
+		p.parts = append(p.parts, n)
+	}
+	if len(p.parts) > 3 || (p.prerelease != "" && len(p.parts) < 3) {
+		return npmPartial{}, fmt.Errorf("invalid version %q", s)
+	}
+	return p, nil
+}
+
+func (p npmPartial) segment(i int) int {
+	if i < len(p.parts) {
+		return p.parts[i]
+	}
+	return 0
+}
+
+// version returns the full version, filling missing segments with zeros.
+func (p npmPartial) version() string {
+	return fmt.Sprintf("v%d.%d.%d%s", p.segment(0), p.segment(1), p.segment(2), p.prerelease)
+}
+
+// bump returns the version after incrementing segment i, e.g. "v1.3.0" for
+// segment 1 of "1.2".
+func (p npmPartial) bump(i int) string {
+	s := [3]int{p.segment(0), p.segment(1), p.segment(2)}
+	s[i]++
+	for j := i + 1; j < 3; j++ {
+		s[j] = 0
+	}
+	return fmt.Sprintf("v%d.%d.%d", s[0], s[1], s[2])
+}
+
+// lowerBound returns the comparators for ">=p" or ">p".
+func (p npmPartial) lowerBound(op string) []npmComparator {
+	switch {
+	case len(p.parts) == 0:
+		if op == ">" {
+			return []npmComparator{{op: "<"}}
+		}
+		return []npmComparator{{op: ">="}}
+	case op == ">" && len(p.parts) < 3:
+		return []npmComparator{{op: ">=", version: p.bump(len(p.parts) - 1)}}
+	}
+	return []npmComparator{{op: op, version: p.version()}}
+}
+
+// upperBound returns the comparators for "<=p" or "<p".
+func (p npmPartial) upperBound(op string) []npmComparator {
+	switch {
+	case len(p.parts) == 0:
+		if op == "<" {
+			return []npmComparator{{op: "<"}}
+		}
+		return nil
+	case len(p.parts) < 3:
+		if op == "<" {
+			return []npmComparator{{op: "<", version: p.version() + "-0"}}
+		}
+		return []npmComparator{{op: "<", version: p.bump(len(p.parts)-1) + "-0"}}
+	}
+	return []npmComparator{{op: op, version: p.version()}}
+}
+
+// tildeUpper allows patch-level changes, or minor ones if only a major is given.
+func (p npmPartial) tildeUpper() npmComparator {
+	switch len(p.parts) {
+	case 0:
+		return npmComparator{op: ">="}
+	case 1:
+		return npmComparator{op: "<", version: p.bump(0) + "-0"}
+	}
+	return npmComparator{op: "<", version: p.bump(1) + "-0"}
+}
+
+// caretUpper allows changes that do not modify the left-most non-zero segment.
+func (p npmPartial) caretUpper() npmComparator {
+	switch {
+	case len(p.parts) == 0:
+		return npmComparator{op: ">="}
+	case p.segment(0) > 0 || len(p.parts) == 1:
+		return npmComparator{op: "<", version: p.bump(0) + "-0"}
+	case p.segment(1) > 0 || len(p.parts) == 2:
+		return npmComparator{op: "<", version: p.bump(1) + "-0"}
+	}
+	return npmComparator{op: "<", version: p.bump(2) + "-0"}
+}
@@ -0,0 +1,83 @@
+package version
+
+import "testing"
+
+func TestNpmRangeSatisfies(t *testing.T) {
+	cases := []struct {
+		rng, version string
+		want         bool
+	}{
+		{"^4.17.0", "4.17.21", true},
+		{"^4.17.0", "5.0.0", false},
+		{"^4.17.0", "5.0.0-0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0.x", "0.0.9", true},
+		{"^0.x", "0.9.0", true},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"^1.2.3-beta.2", "1.2.4-beta.1", false},
+		{"^1.2.3-beta.2", "1.9.0", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"~>1.2", "1.2.5", true},
+		{"1.x", "1.5.0", true},
+		{"1.x", "2.0.0", false},
+		{"1.2", "1.2.7", true},
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"v1.2.3", "1.2.3", true},
+		{"*", "3.0.0", true},
+		{"*", "3.0.0-rc.1", false},
+		{"", "0.0.1", true},
+		{">=1.2.0 <2", "1.9.9", true},
+		{">=1.2.0 <2", "2.0.0-rc.1", false},
+		{">= 1.2.0", "1.2.0", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0-rc.1", false},
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2 - 2.3", "2.3.9", true},
+		{"1.2 - 2.3", "2.4.0", false},
+		{"1.2.3 - 2", "1.2.2", false},
+		{"^1.0.0 || ^2.0.0", "2.5.0", true},
+		{"^1.0.0 || ^2.0.0", "3.0.0", false},
+		{"1.2.3", "not-a-version", false},
+	}
+	for _, c := range cases {
+		r, err := ParseNpmRange(c.rng)
+		if err != nil {
+			t.Errorf("ParseNpmRange(%q): %v", c.rng, err)
+			continue
+		}
+		if got := r.Satisfies(c.version); got != c.want {
+			t.Errorf("%q satisfied by %q = %v, want %v", c.rng, c.version, got, c.want)
+		}
+	}
+}
+
+func TestParseNpmRange_Invalid(t *testing.T) {
+	for _, rng := range []string{"latest", "github:lodash/lodash", "file:../lib", "1.2.3.4", "^1.2-beta"} {
+		if _, err := ParseNpmRange(rng); err == nil {
+			t.Errorf("ParseNpmRange(%q): expected error", rng)
+		}
+	}
+}
+
+func TestNpmRangeMaxSatisfying(t *testing.T) {
+	r, err := ParseNpmRange("~4.17.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []string{"4.16.6", "4.17.21", "4.17.20", "4.18.0", "5.0.0-beta.1"}
+	if got := r.MaxSatisfying(versions); got != "4.17.21" {
+		t.Errorf("MaxSatisfying = %q, want 4.17.21", got)
+	}
+	r, _ = ParseNpmRange("^6.0.0")
+	if got := r.MaxSatisfying(versions); got != "" {
+		t.Errorf("MaxSatisfying = %q, want none", got)
+	}
+}
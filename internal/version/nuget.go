@@ -0,0 +1,71 @@
+package version
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// NuGet orders NuGet package versions: up to four numeric segments, an
+// optional prerelease label compared case-insensitively, and build metadata,
+// which is ignored.
+var NuGet Scheme = nugetVersion{}
+
+type nugetVersion struct{}
+
+func parseNuGet(v string) (release []int, pre string, ok bool) {
+	v, _, _ = strings.Cut(strings.TrimSpace(v), "+")
+	base, pre, _ := strings.Cut(v, "-")
+	release, ok = numericSegments(strings.Split(base, "."))
+	return release, pre, ok && len(release) <= 4
+}
+
+func (nugetVersion) Compare(a, b string) (int, bool) {
+	releaseA, preA, okA := parseNuGet(a)
+	releaseB, preB, okB := parseNuGet(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if c := compareSegments(releaseA, releaseB); c != 0 {
+		return c, true
+	}
+	return comparePrerelease(strings.ToLower(preA), strings.ToLower(preB)), true
+}
+
+func (nugetVersion) Release(v string) ([]int, bool) {
+	release, _, ok := parseNuGet(v)
+	return release, ok
+}
+
+// comparePrerelease compares dot-separated prerelease labels as SemVer does:
+// no label sorts last, numeric identifiers sort before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	idsA, idsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		numA, errA := strconv.Atoi(idsA[i])
+		numB, errB := strconv.Atoi(idsB[i])
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = cmp.Compare(numA, numB)
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = cmp.Compare(idsA[i], idsB[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(idsA), len(idsB))
+}
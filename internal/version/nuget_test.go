@@ -0,0 +1,14 @@
+package version
+
+import "testing"
+
+func TestNuGetCompare(t *testing.T) {
+	testCompare(t, NuGet, []compareCase{
+		{"1.0.0", "1.0.0.0", 0},
+		{"1.0.0.1", "1.0.0", 1},
+		{"2.0.0-beta", "2.0.0", -1},
+		{"2.0.0-Beta.2", "2.0.0-beta.10", -1},
+		{"2.0.0-beta.1", "2.0.0-beta", 1},
+		{"1.0.0+build.5", "1.0.0", 0},
+	})
+}
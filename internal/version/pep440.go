@@ -0,0 +1,114 @@
+package version
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PEP440 orders Python package versions (epoch, release, pre, post and dev
+// releases). Local version labels are ignored.
+var PEP440 Scheme = pep440{}
+
+var pep440Re = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d*))?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?` +
+	`(?:[-_.]?(dev)[-_.]?(\d*))?` +
+	`(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+
+type pep440 struct{}
+
+type pep440Version struct {
+	epoch   int
+	release []int
+	// pre, post and dev are (rank, number) sort keys; see parsePEP440.
+	pre, post, dev [2]int
+}
+
+func parsePEP440(v string) (pep440Version, bool) {
+	m := pep440Re.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v)))
+	if m == nil {
+		return pep440Version{}, false
+	}
+	var p pep440Version
+	p.epoch, _ = strconv.Atoi(m[1])
+	p.release, _ = numericSegments(strings.Split(m[2], "."))
+	for len(p.release) > 1 && p.release[len(p.release)-1] == 0 {
+		p.release = p.release[:len(p.release)-1]
+	}
+
+	// A release without a pre-release sorts after its pre-releases, except
+	// for a bare dev release of it (1.0.dev1 < 1.0a1).
+	implicitPost, postTag, postNum, devTag, devNum := m[5], m[6], m[7], m[8], m[9]
+	hasPost := implicitPost != "" || postTag != ""
+	p.pre = [2]int{math.MaxInt, 0}
+	switch m[3] {
+	case "a", "alpha":
+		p.pre = [2]int{0, atoi(m[4])}
+	case "b", "beta":
+		p.pre = [2]int{1, atoi(m[4])}
+	case "c", "rc", "pre", "preview":
+		p.pre = [2]int{2, atoi(m[4])}
+	case "":
+		if !hasPost && devTag != "" {
+			p.pre = [2]int{math.MinInt, 0}
+		}
+	}
+	p.post = [2]int{math.MinInt, 0}
+	if hasPost {
+		p.post = [2]int{0, atoi(implicitPost + postNum)}
+	}
+	p.dev = [2]int{math.MaxInt, 0}
+	if devTag != "" {
+		p.dev = [2]int{0, atoi(devNum)}
+	}
+	return p, true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func (pep440) Compare(a, b string) (int, bool) {
+	pa, okA := parsePEP440(a)
+	pb, okB := parsePEP440(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if c := cmp.Compare(pa.epoch, pb.epoch); c != 0 {
+		return c, true
+	}
+	if c := compareSegments(pa.release, pb.release); c != 0 {
+		return c, true
+	}
+	for _, keys := range [][2][2]int{{pa.pre, pb.pre}, {pa.post, pb.post}, {pa.dev, pb.dev}} {
+		if c := cmp.Compare(keys[0][0], keys[1][0]); c != 0 {
+			return c, true
+		}
+		if c := cmp.Compare(keys[0][1], keys[1][1]); c != 0 {
+			return c, true
+		}
+	}
+	return 0, true
+}
+
+func (pep440) Release(v string) ([]int, bool) {
+	m := pep440Re.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v)))
+	if m == nil {
+		return nil, false
+	}
+	return numericSegments(strings.Split(m[2], "."))
+}
+
+// compareSegments compares numeric segments, padding the shorter with zeros.
+func compareSegments(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if c := cmp.Compare(segment(a, i), segment(b, i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
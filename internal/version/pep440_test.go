@@ -0,0 +1,22 @@
+package version
+
+import "testing"
+
+func TestPEP440Compare(t *testing.T) {
+	testCompare(t, PEP440, []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1.0.dev1", "1.0a1", -1},
+		{"1.0a1", "1.0b1", -1},
+		{"1.0b2", "1.0rc1", -1},
+		{"1.0rc1", "1.0", -1},
+		{"1.0", "1.0.post1", -1},
+		{"1.0.post1", "1.0-2", -1},
+		{"1.0a1.dev1", "1.0a1", -1},
+		{"1!0.1", "2.0", 1},
+		{"2.0+local.1", "2.0", 0},
+		{"1.10", "1.9", 1},
+	})
+	if _, ok := PEP440.Compare("not a version", "1.0"); ok {
+		t.Error("expected invalid version to be rejected")
+	}
+}
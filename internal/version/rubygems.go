@@ -0,0 +1,80 @@
+package version
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RubyGems orders gem versions like Gem::Version: segments split at dots and
+// digit/letter transitions, and any letter marks a prerelease (1.0.0.pre1 < 1.0.0).
+var RubyGems Scheme = rubyGemsVersion{}
+
+var rubyGemsRe = regexp.MustCompile(`^[0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+var rubyGemsSegmentRe = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+
+type rubyGemsVersion struct{}
+
+// parseRubyGems returns the segments of v as ints and strings. As in
+// Gem::Version, "-" is a prerelease marker and trailing zeros are dropped.
+func parseRubyGems(v string) ([]interface{}, bool) {
+	v = strings.TrimSpace(v)
+	if !rubyGemsRe.MatchString(v) {
+		return nil, false
+	}
+	v = strings.ReplaceAll(v, "-", ".pre.")
+	segments := []interface{}{}
+	for _, s := range rubyGemsSegmentRe.FindAllString(v, -1) {
+		if n, err := strconv.Atoi(s); err == nil {
+			segments = append(segments, n)
+		} else {
+			segments = append(segments, s)
+		}
+	}
+	for len(segments) > 1 && segments[len(segments)-1] == 0 {
+		segments = segments[:len(segments)-1]
+	}
+	return segments, true
+}
+
+func (rubyGemsVersion) Compare(a, b string) (int, bool) {
+	segA, okA := parseRubyGems(a)
+	segB, okB := parseRubyGems(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := 0; i < len(segA) || i < len(segB); i++ {
+		var x, y interface{} = 0, 0
+		if i < len(segA) {
+			x = segA[i]
+		}
+		if i < len(segB) {
+			y = segB[i]
+		}
+		nx, xIsNum := x.(int)
+		ny, yIsNum := y.(int)
+		var c int
+		switch {
+		case xIsNum && yIsNum:
+			c = cmp.Compare(nx, ny)
+		case xIsNum:
+			c = 1 // a prerelease sorts before the release
+		case yIsNum:
+			c = -1
+		default:
+			c = cmp.Compare(x.(string), y.(string))
+		}
+		if c != 0 {
+			return c, true
+		}
+	}
+	return 0, true
+}
+
+func (rubyGemsVersion) Release(v string) ([]int, bool) {
+	if _, ok := parseRubyGems(v); !ok {
+		return nil, false
+	}
+	return leadingNumbers(v)
+}
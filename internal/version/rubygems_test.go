@@ -0,0 +1,14 @@
+package version
+
+import "testing"
+
+func TestRubyGemsCompare(t *testing.T) {
+	testCompare(t, RubyGems, []compareCase{
+		{"1.0", "1.0.0", 0},
+		{"1.0.0.pre1", "1.0.0", -1},
+		{"1.0.0.alpha", "1.0.0.beta", -1},
+		{"7.1.0.rc2", "7.1.0", -1},
+		{"1.0.0-1", "1.0.0", -1},
+		{"13.10.0", "13.9.0", 1},
+	})
+}
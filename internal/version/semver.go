@@ -0,0 +1,61 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// SemVer orders Semantic Versioning 2.0 versions as used by npm and Cargo.
+// A leading "v" or "=" is accepted.
+var SemVer Scheme = semVer{}
+
+// Go orders Go module versions, including pseudo-versions and +incompatible.
+var Go Scheme = goVersion{}
+
+type semVer struct{}
+
+func (semVer) Compare(a, b string) (int, bool) {
+	return goVersion{}.Compare(semverPrefix(a), semverPrefix(b))
+}
+
+func (semVer) Release(v string) ([]int, bool) {
+	return goVersion{}.Release(semverPrefix(v))
+}
+
+func semverPrefix(v string) string {
+	v = strings.TrimLeft(strings.TrimSpace(v), "=v")
+	return "v" + v
+}
+
+type goVersion struct{}
+
+func (goVersion) Compare(a, b string) (int, bool) {
+	if !semver.IsValid(a) || !semver.IsValid(b) {
+		return 0, false
+	}
+	return semver.Compare(a, b), true
+}
+
+func (goVersion) Release(v string) ([]int, bool) {
+	if !semver.IsValid(v) {
+		return nil, false
+	}
+	core := strings.TrimPrefix(semver.Canonical(v), "v")
+	core = strings.SplitN(strings.SplitN(core, "+", 2)[0], "-", 2)[0]
+	return numericSegments(strings.Split(core, "."))
+}
+
+// numericSegments converts dot-separated numbers.
+func numericSegments(parts []string) ([]int, bool) {
+	segments := make([]int, 0, len(parts))
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		segments = append(segments, n)
+	}
+	return segments, true
+}
@@ -0,0 +1,80 @@
+// Package version compares versions according to the rules of each package
+// ecosystem and classifies updates between them.
+package version
+
+// Scheme orders the versions of one ecosystem.
+type Scheme interface {
+	// Compare returns -1, 0 or +1 as a is older than, equal to or newer than b.
+	// ok is false when either version cannot be parsed.
+	Compare(a, b string) (c int, ok bool)
+	// Release returns the numeric release segments of v, e.g. [1 2 3] for
+	// "1.2.3-beta.1". ok is false when v cannot be parsed.
+	Release(v string) (segments []int, ok bool)
+}
+
+// UpdateType classifies an update by the release segment that changes.
+type UpdateType string
+
+const (
+	NoUpdate UpdateType = ""
+	Patch    UpdateType = "patch"
+	Minor    UpdateType = "minor"
+	Major    UpdateType = "major"
+)
+
+var schemes = map[string]Scheme{
+	"npm":        SemVer,
+	"yarn":       SemVer,
+	"yarn-berry": SemVer,
+	"pnpm":       SemVer,
+	"bun":        SemVer,
+	"cargo":      SemVer,
+	"go":         Go,
+	"pip":        PEP440,
+	"pipenv":     PEP440,
+	"poetry":     PEP440,
+	"uv":         PEP440,
+	"maven":      Maven,
+	"gradle":     Maven,
+	"bundler":    RubyGems,
+	"composer":   Composer,
+	"nuget":      NuGet,
+}
+
+// ForEcosystem returns the scheme of a registered ecosystem name. Unknown
+// ecosystems use SemVer.
+func ForEcosystem(name string) Scheme {
+	if s, ok := schemes[name]; ok {
+		return s
+	}
+	return SemVer
+}
+
+// Classify returns the kind of update from current to latest. It returns
+// NoUpdate when latest is not newer than current, so downgrades are never
+// reported. ok is false when the scheme cannot order the two versions.
+func Classify(s Scheme, current, latest string) (UpdateType, bool) {
+	c, ok := s.Compare(current, latest)
+	if !ok {
+		return NoUpdate, false
+	}
+	if c >= 0 {
+		return NoUpdate, true
+	}
+	from, _ := s.Release(current)
+	to, _ := s.Release(latest)
+	switch {
+	case segment(from, 0) != segment(to, 0):
+		return Major, true
+	case segment(from, 1) != segment(to, 1):
+		return Minor, true
+	}
+	return Patch, true
+}
+
+func segment(segments []int, i int) int {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
@@ -0,0 +1,73 @@
+package version
+
+import "testing"
+
+type compareCase struct {
+	a, b string
+	want int
+}
+
+func testCompare(t *testing.T, s Scheme, cases []compareCase) {
+	t.Helper()
+	for _, c := range cases {
+		got, ok := s.Compare(c.a, c.b)
+		if !ok {
+			t.Errorf("Compare(%q, %q): versions not recognized", c.a, c.b)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		scheme          Scheme
+		current, latest string
+		want            UpdateType
+	}{
+		{SemVer, "4.17.20", "4.17.21", Patch},
+		{SemVer, "4.17.21", "4.18.0", Minor},
+		{SemVer, "4.17.21", "5.0.0", Major},
+		{SemVer, "5.0.0-beta.2", "4.17.21", NoUpdate},
+		{SemVer, "5.0.0-beta.2", "5.0.0", Patch},
+		{SemVer, "1.0.0", "1.0.0", NoUpdate},
+		{Go, "v1.8.0", "v1.9.0", Minor},
+		{Go, "v0.0.0-20240101120000-abcdef123456", "v0.1.0", Minor},
+		{PEP440, "2.31.0", "3.0", Major},
+		{Maven, "5.3.31", "6.1.2", Major},
+		{RubyGems, "13.0.6", "13.1.0", Minor},
+		{Composer, "v6.4.1", "v6.4.2", Patch},
+		{NuGet, "13.0.1", "13.0.3", Patch},
+	}
+	for _, c := range cases {
+		got, ok := Classify(c.scheme, c.current, c.latest)
+		if !ok || got != c.want {
+			t.Errorf("Classify(%T, %q, %q) = %q, %v; want %q", c.scheme, c.current, c.latest, got, ok, c.want)
+		}
+	}
+	if _, ok := Classify(SemVer, "latest", "1.0.0"); ok {
+		t.Error("expected unparsable version to be reported")
+	}
+}
+
+func TestForEcosystem(t *testing.T) {
+	if ForEcosystem("pnpm") != SemVer || ForEcosystem("poetry") != PEP440 || ForEcosystem("gradle") != Maven || ForEcosystem("unknown") != SemVer {
+		t.Error("unexpected scheme mapping")
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	testCompare(t, SemVer, []compareCase{
+		{"1.2.3", "v1.2.3", 0},
+		{"=1.2.3", "1.2.4", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+	})
+	testCompare(t, Go, []compareCase{
+		{"v2.0.0+incompatible", "v1.9.0", 1},
+		{"v0.0.0-20240101120000-abcdef123456", "v0.0.1", -1},
+	})
+}